@@ -1,4 +1,5 @@
 data "privx_access_group" "example" {
   name = "Default"
   // default = true
+  // id = "00000000-0000-4000-8000-000000000000"
 }
@@ -0,0 +1,3 @@
+data "privx_access_groups" "example" {
+  name_regex = "^team-"
+}
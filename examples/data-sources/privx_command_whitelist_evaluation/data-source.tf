@@ -0,0 +1,16 @@
+data "privx_command_whitelist_evaluation" "read_only" {
+  whitelist_id   = privx_command_whitelist.read_only.id
+  rshell_variant = "bash"
+  commands = [
+    "systemctl status sshd",
+    "systemctl restart sshd",
+  ]
+}
+
+// Fails the plan when the whitelist allows more than intended
+check "read_only_whitelist" {
+  assert {
+    condition     = data.privx_command_whitelist_evaluation.read_only.denied_commands == ["systemctl restart sshd"]
+    error_message = "The read-only whitelist must not allow restarting services"
+  }
+}
@@ -0,0 +1,10 @@
+provider "privx" {
+}
+
+data "privx_host" "by_name" {
+  common_name = "my-host"
+}
+
+data "privx_host" "by_address" {
+  address = "10.0.0.10"
+}
@@ -0,0 +1,12 @@
+provider "privx" {
+}
+
+data "privx_hosts" "production" {
+  zones           = ["production"]
+  cloud_providers = ["AWS"]
+  tags            = ["web"]
+}
+
+output "production_host_ids" {
+  value = data.privx_hosts.production.hosts[*].id
+}
@@ -0,0 +1,4 @@
+data "privx_local_users" "break_glass" {
+  name_regex = "^break-glass"
+  tag        = "break-glass"
+}
@@ -0,0 +1,3 @@
+data "privx_roles" "example" {
+  permission = "users-view"
+}
@@ -0,0 +1,9 @@
+resource "privx_command_whitelist" "read_only" {
+  name    = "read-only"
+  comment = "Service inspection commands"
+  type    = "GLOB" # GLOB | REGEX
+  whitelist_patterns = [
+    "systemctl status *",
+    "journalctl -u *",
+  ]
+}
@@ -69,9 +69,8 @@ resource "privx_host" "foo" {
 #  tags                  = []
 #  addresses             = []
 #
-#  #  certificate_template  = "" # FIXME: not implemented in privx-sdk-go v1.29
-#  #  host_certificate_raw  = "" # FIXME: not implemented in privx-sdk-go v1.29
-#  #  use_for_password_rotation = false # FIXME: not implemented in privx-sdk-go v1.29
+#  certificate_template = "" # Name of a PrivX certificate template
+#  host_certificate_raw = file("${path.module}/host-certificate.pem")
 #
 #  ssh_host_public_keys = [
 #    {
@@ -84,15 +83,15 @@ resource "privx_host" "foo" {
 #      service = "SSH" # SSH | RDP | VNC | HTTP | HTTPS
 #      address = "0.0.0.0"
 #      port    = 22
-#      #     use_for_password_rotation = false # FIXME: not implemented in privx-sdk-go v1.29
+#      use_for_password_rotation = true
 #    }
 #  ]
 #
 #  principals = [
 #    {
 #      principal = "examplename"
-#      # rotate                    = false # FIXME: not implemented in privx-sdk-go v1.29
-#      # use_for_password_rotation = false # FIXME: not implemented in privx-sdk-go v1.29
+#      rotate                    = true
+#      use_for_password_rotation = true
 #      use_user_account = false
 #      passphrase       = "toto"
 #      roles = [
@@ -100,71 +99,67 @@ resource "privx_host" "foo" {
 #          id = "1fb15cfa-6137-4821-b60c-ffc0ba11bb86"
 #        }
 #      ]
-#      #applications = [ # FIXME: not implemented in privx-sdk-go v1.29
-#      #  {
-#      #    name = "example-application"
-#      #    # application       = "" # FIXME: not implemented in privx-sdk-go v1.29
-#      #    # arguments         = "" # FIXME: not implemented in privx-sdk-go v1.29
-#      #    # working_directory = "" # FIXME: not implemented in privx-sdk-go v1.29
-#      #  }
-#      #]
-#      #service_options = { # FIXME: not implemented in privx-sdk-go v1.29
-#      #  ssh = {
-#      #    shell         = false
-#      #    file_transfer = false
-#      #    exec          = false
-#      #    tunnels       = false
-#      #    x11           = false
-#      #    other         = false
-#      #  }
-#      #  rdp = {
-#      #    file_transfer = false
-#      #    audio         = false
-#      #    clipboard     = false
-#      #    web           = false
-#      #  }
-#      #  web = {
-#      #    file_transfer = false
-#      #    audio         = false
-#      #    clipboard     = false
-#      #  }
-#      #}
-#      #command_restrictions = { # FIXME: not implemented in privx-sdk-go v1.29
-#      #  enabled = false
-#      #  default_whitelist = {
-#      #    id      = ""
-#      #    name    = ""
-#      #    deleted = ""
-#      #  }
-#      #  rshell_variant = "" # bash | posix
-#      #  banner         = ""
-#      #  allow_no_match = false
-#      #  audit_match    = false
-#      #  audit_no_match = false
-#      #  whitelists = [
-#      #    {
-#      #      whitelist = {
-#      #        id   = ""
-#      #        name = ""
-#      #      }
-#      #      roles = [
-#      #        {
-#      #          id   = ""
-#      #          name = ""
-#      #        }
-#      #      ]
-#      #    }
-#      #  ]
-#      #}
+#      applications = [
+#        {
+#          name              = "services"
+#          application       = "C:\\Windows\\System32\\mmc.exe"
+#          arguments         = "services.msc"
+#          working_directory = "C:\\Windows\\System32"
+#        }
+#      ]
+#      service_options = { # unset options stay enabled
+#        ssh = {
+#          shell         = true
+#          file_transfer = false
+#          exec          = true
+#          tunnels       = false
+#          x11           = false
+#          other         = false
+#        }
+#        rdp = {
+#          file_transfer = false
+#          audio         = true
+#          clipboard     = false
+#        }
+#        web = {
+#          file_transfer = false
+#          audio         = true
+#          clipboard     = false
+#        }
+#      }
+#      command_restrictions = {
+#        enabled = true
+#        default_whitelist = {
+#          id = privx_command_whitelist.read_only.id
+#        }
+#        rshell_variant = "bash" # bash | posix
+#        banner         = ""
+#        allow_no_match = false
+#        audit_match    = false
+#        audit_no_match = true
+#        whitelists = [
+#          {
+#            whitelist = {
+#              id = privx_command_whitelist.services.id
+#            }
+#            roles = [
+#              {
+#                id = "1fb15cfa-6137-4821-b60c-ffc0ba11bb86"
+#              }
+#            ]
+#          }
+#        ]
+#      }
 #    }
 #  ]
-#  # password_rotation = { # FIXME: not implemented in privx-sdk-go v1.29
-#  #   use_main_account   = true
-#  #   operating_system   = "LINUX" # LINUX | WINDOWS
-#  #   winrm_address      = ""
-#  #   winrm_port         = 0
-#  #   protocol           = "SSH" # SSH | WINRM
-#  #   password_policy_id = ""
-#  #   script_template_id = ""
-#  # }
+#  password_rotation_enabled = true
+#  password_rotation = {
+#    use_main_account   = true
+#    operating_system   = "LINUX" # LINUX | WINDOWS
+#    protocol           = "SSH"   # SSH | WINRM
+#    password_policy_id = ""
+#    script_template_id = ""
+#    # winrm_address    = ""      # WINRM only
+#    # winrm_port       = 0       # WINRM only, zero for winrm default
+#  }
 #}
@@ -0,0 +1,10 @@
+resource "privx_host_principal" "app" {
+  host_id   = privx_host.foo.id
+  principal = "app"
+
+  roles = [
+    {
+      id = privx_role.app.id
+    },
+  ]
+}
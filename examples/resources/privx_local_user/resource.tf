@@ -0,0 +1,14 @@
+variable "break_glass_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "privx_local_user" "break_glass" {
+  username  = "break-glass"
+  email     = "break-glass@example.com"
+  full_name = "Break Glass Administrator"
+  tags      = ["break-glass"]
+
+  // Changing the password rotates it
+  password = var.break_glass_password
+}
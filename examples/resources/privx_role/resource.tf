@@ -4,11 +4,26 @@ resource "privx_role" "foo" {
   access_group_id = "565381ce-0911-4ba8-8606-8eecd8074556"
   permissions     = []
   permit_agent    = false
-  source_rules = jsonencode({
-    type = "GROUP" // GROUP | RULE
-    //source        = ""
-    //search_string = ""
-    match = "ANY" // ANY | ALL
-    rules = []
-  })
+
+  source_rule {
+    type  = "GROUP" // GROUP | RULE
+    match = "ANY"   // ANY | ALL
+
+    rules {
+      type          = "RULE"
+      source        = "9c0bbe2f-7b53-4d1a-8a1b-0c9b0e3b4a61"
+      search_string = "CN=admins,OU=groups,DC=example,DC=com"
+    }
+
+    rules {
+      type  = "GROUP"
+      match = "ALL"
+
+      rules {
+        type    = "RULE"
+        source  = "9c0bbe2f-7b53-4d1a-8a1b-0c9b0e3b4a61"
+        pattern = "^ops-.*"
+      }
+    }
+  }
 }
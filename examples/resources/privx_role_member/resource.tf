@@ -0,0 +1,6 @@
+resource "privx_role_member" "on_call" {
+  role_id     = privx_role.foo.id
+  user_id     = "0d8c3c5e-8a3f-4a8e-b0a4-5c1f2e7d9b10"
+  grant_start = "2024-03-01T08:00:00Z"
+  grant_end   = "2024-03-08T08:00:00Z"
+}
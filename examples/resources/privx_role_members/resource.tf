@@ -0,0 +1,15 @@
+resource "privx_role_members" "operators" {
+  role_id = privx_role.foo.id
+
+  members = [
+    {
+      user_id = "a2c2c1d4-5d6b-4f43-9a0b-1f6f0d3e9b27"
+    },
+    {
+      // Temporary membership
+      user_id     = "0d8c3c5e-8a3f-4a8e-b0a4-5c1f2e7d9b10"
+      grant_start = "2024-03-01T08:00:00Z"
+      grant_end   = "2024-03-31T18:00:00Z"
+    },
+  ]
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+)
+
+// Ensure passwordAuthorizer satisfies the PrivX SDK interfaces.
+var _ restapi.Authorizer = &passwordAuthorizer{}
+var _ refresher = &passwordAuthorizer{}
+
+// maxRefreshMargin is how long before it expires an access token is renewed.
+// Short lived tokens are renewed halfway through their lifetime instead.
+const maxRefreshMargin = time.Minute
+
+// defaultTokenLifetime is how long access tokens are assumed to be valid
+// when the token endpoint does not tell.
+const defaultTokenLifetime = 5 * time.Minute
+
+// refresher is an authorizer whose access token can be renewed.
+type refresher interface {
+	// invalidate discards the access token if it is still the current one,
+	// so that the next call to AccessToken fetches a new one.
+	invalidate(token string)
+}
+
+// passwordAuthorizer runs the OAuth2 resource owner password grant. Unlike
+// the SDK authorizer it keeps track of when the access token expires and
+// renews it beforehand, so long applies keep working.
+//
+// The credentials are fetched again for each grant. Credentials holding a
+// bearer token are used as is, until the token is rejected.
+type passwordAuthorizer struct {
+	connector   *connector
+	credentials func() (Credentials, error)
+	now         func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	bearer    bool
+	refreshAt time.Time
+}
+
+// accessTokenResponse is the token endpoint response.
+type accessTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// passwordGrantRequest is the token endpoint request.
+type passwordGrantRequest struct {
+	GrantType string `json:"grant_type"`
+	Access    string `json:"username"`
+	Secret    string `json:"password"`
+}
+
+func newPasswordAuthorizer(c *connector, credentials func() (Credentials, error)) *passwordAuthorizer {
+	return &passwordAuthorizer{
+		connector:   c,
+		credentials: credentials,
+		now:         time.Now,
+	}
+}
+
+// AccessToken returns a valid access token, fetching a new one when the
+// current one is about to expire. Concurrent callers wait for a single
+// token request.
+func (a *passwordAuthorizer) AccessToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && (a.bearer || a.now().Before(a.refreshAt)) {
+		return a.token, nil
+	}
+
+	credentials, err := a.credentials()
+	if err != nil {
+		return "", err
+	}
+	if credentials.BearerToken != "" {
+		a.token = "Bearer " + credentials.BearerToken
+		a.bearer = true
+		return a.token, nil
+	}
+
+	digest := base64.StdEncoding.EncodeToString([]byte(credentials.OAuthClientID + ":" + credentials.OAuthClientSecret))
+
+	var token accessTokenResponse
+	_, err = a.connector.
+		URL("/auth/api/v1/oauth/token").
+		Header("Content-Type", "application/x-www-form-urlencoded").
+		Header("Authorization", "Basic "+digest).
+		Post(passwordGrantRequest{
+			GrantType: "password",
+			Access:    credentials.APIClientID,
+			Secret:    credentials.APIClientSecret,
+		}, &token)
+	if err != nil {
+		return "", err
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("no access token in the token endpoint response")
+	}
+
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = defaultTokenLifetime
+	}
+	a.token = "Bearer " + token.AccessToken
+	a.bearer = false
+	a.refreshAt = a.now().Add(lifetime - min(maxRefreshMargin, lifetime/2))
+
+	return a.token, nil
+}
+
+// Cookie is not used by the password grant.
+func (a *passwordAuthorizer) Cookie() string {
+	return ""
+}
+
+func (a *passwordAuthorizer) invalidate(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Requests rejected with an already replaced token must not discard the
+	// new one
+	if a.token == token {
+		a.token = ""
+	}
+}
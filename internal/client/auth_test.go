@@ -0,0 +1,143 @@
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-privx/internal/privxmock"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+)
+
+func testConnector(t *testing.T, server *privxmock.Server) restapi.Connector {
+	t.Helper()
+
+	connector, err := NewConnector(server.URL, "",
+		privxmock.APIClientID, privxmock.APIClientSecret,
+		privxmock.OAuthClientID, privxmock.OAuthClientSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *connector
+}
+
+func listAccessGroups(connector restapi.Connector) error {
+	var result struct {
+		Count int `json:"count"`
+	}
+	_, err := connector.URL("/authorizer/api/v1/accessgroups").Get(&result)
+	return err
+}
+
+func TestAccessTokenReused(t *testing.T) {
+	server := privxmock.New()
+	defer server.Close()
+
+	connector := testConnector(t, server)
+	for i := 0; i < 3; i++ {
+		if err := listAccessGroups(connector); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if grants := server.TokenGrants(); grants != 1 {
+		t.Errorf("expected 1 token grant, got %d", grants)
+	}
+}
+
+func TestAccessTokenRefreshedBeforeExpiry(t *testing.T) {
+	server := privxmock.New()
+	defer server.Close()
+
+	c := testConnector(t, server)
+	auth := c.(*connector).auth.(*passwordAuthorizer) //nolint:forcetypeassert
+
+	// The mock grants tokens valid for an hour
+	now := time.Now()
+	auth.now = func() time.Time { return now.Add(59*time.Minute + time.Second) }
+
+	if err := listAccessGroups(c); err != nil {
+		t.Fatal(err)
+	}
+	if grants := server.TokenGrants(); grants != 2 {
+		t.Errorf("expected 2 token grants, got %d", grants)
+	}
+}
+
+func TestAccessTokenWithoutLifetimeReused(t *testing.T) {
+	server := privxmock.New()
+	defer server.Close()
+	server.SetTokenLifetime(0)
+
+	c := testConnector(t, server)
+	auth := c.(*connector).auth.(*passwordAuthorizer) //nolint:forcetypeassert
+
+	now := time.Now()
+	auth.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		if err := listAccessGroups(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if grants := server.TokenGrants(); grants != 1 {
+		t.Errorf("expected 1 token grant, got %d", grants)
+	}
+
+	// The token is renewed within the default lifetime
+	auth.now = func() time.Time { return now.Add(defaultTokenLifetime) }
+	if err := listAccessGroups(c); err != nil {
+		t.Fatal(err)
+	}
+	if grants := server.TokenGrants(); grants != 2 {
+		t.Errorf("expected 2 token grants, got %d", grants)
+	}
+}
+
+func TestUnauthorizedRetriedWithNewToken(t *testing.T) {
+	server := privxmock.New()
+	defer server.Close()
+
+	connector := testConnector(t, server)
+	server.RevokeTokens()
+
+	// Concurrent requests rejected with the revoked token share a new one
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- listAccessGroups(connector)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if grants := server.TokenGrants(); grants != 2 {
+		t.Errorf("expected 2 token grants, got %d", grants)
+	}
+}
+
+func TestUnauthorizedBearerTokenNotRetried(t *testing.T) {
+	server := privxmock.New()
+	defer server.Close()
+
+	connector, err := NewConnector(server.URL, "revoked", "", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = listAccessGroups(*connector)
+	if err == nil {
+		t.Fatal("expected an error with an invalid bearer token")
+	}
+	if grants := server.TokenGrants(); grants != 0 {
+		t.Errorf("expected no token grant, got %d", grants)
+	}
+}
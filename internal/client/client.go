@@ -7,26 +7,44 @@ import (
 	"github.com/SSHcom/privx-sdk-go/restapi"
 )
 
-func authorize(apiBaseURL, bearerToken, apiClientID, apiClientSecret, oauthClientID, oauthClientSecret string) restapi.Authorizer {
+func authorize(apiBaseURL, bearerToken, apiClientID, apiClientSecret, oauthClientID, oauthClientSecret string, opts ...Option) restapi.Authorizer {
 	if bearerToken != "" {
 		return oauth.WithToken("Bearer " + bearerToken)
 	}
-	return oauth.WithClientID(
-		restapi.New(
-			restapi.BaseURL(apiBaseURL),
-		),
-		oauth.Access(apiClientID),
-		oauth.Secret(apiClientSecret),
-		oauth.Digest(oauthClientID, oauthClientSecret),
+	credentials := Credentials{
+		APIClientID:       apiClientID,
+		APIClientSecret:   apiClientSecret,
+		OAuthClientID:     oauthClientID,
+		OAuthClientSecret: oauthClientSecret,
+	}
+	return newPasswordAuthorizer(
+		newConnector(apiBaseURL, nil, opts...),
+		func() (Credentials, error) { return credentials, nil },
 	)
 }
 
-func NewConnector(apiBaseURL, bearerToken, apiClientID, apiClientSecret, oauthClientID, oauthClientSecret string) (*restapi.Connector, error) {
-	auth := authorize(apiBaseURL, bearerToken, apiClientID, apiClientSecret, oauthClientID, oauthClientSecret)
+// NewConnector authenticates to the PrivX API and returns a connector to it.
+// Both the authentication and the API requests are configured with opts.
+func NewConnector(apiBaseURL, bearerToken, apiClientID, apiClientSecret, oauthClientID, oauthClientSecret string, opts ...Option) (*restapi.Connector, error) {
+	return connect(apiBaseURL, authorize(apiBaseURL, bearerToken, apiClientID, apiClientSecret, oauthClientID, oauthClientSecret, opts...), opts...)
+}
+
+// NewCredentialProcessConnector authenticates to the PrivX API with the
+// credentials printed by the command, see CredentialProcess, and returns a
+// connector to it. The command is run again whenever an access token is
+// needed, when the current one expired or was rejected.
+func NewCredentialProcessConnector(apiBaseURL, command string, opts ...Option) (*restapi.Connector, error) {
+	auth := newPasswordAuthorizer(newConnector(apiBaseURL, nil, opts...), CredentialProcess(command))
+	return connect(apiBaseURL, auth, opts...)
+}
+
+// connect checks that auth authenticates to the PrivX API and returns a
+// connector using it.
+func connect(apiBaseURL string, auth restapi.Authorizer, opts ...Option) (*restapi.Connector, error) {
 	_, err := auth.AccessToken()
 	if err != nil {
 		return nil, fmt.Errorf("PrivX client authentication failed: %v", err)
 	}
-	connector := restapi.New(restapi.Auth(auth), restapi.Verbose(), restapi.BaseURL(apiBaseURL))
+	var connector restapi.Connector = newConnector(apiBaseURL, auth, opts...)
 	return &connector, nil
 }
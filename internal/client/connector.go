@@ -0,0 +1,359 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+)
+
+// Ensure connector satisfies the PrivX SDK interfaces.
+var _ restapi.Connector = &connector{}
+var _ restapi.CURL = &curl{}
+
+// connector is a restapi.Connector which, unlike the SDK one, keeps the HTTP
+// status of failed requests so callers can classify API errors.
+type connector struct {
+	auth      restapi.Authorizer
+	baseURL   string
+	http      *http.Client
+	transport *http.Transport
+	retry     retryPolicy
+	limit     *limiter
+	tracer    Tracer
+}
+
+// Option configures the connectors created by NewConnector.
+type Option func(*connector)
+
+func newConnector(baseURL string, auth restapi.Authorizer, opts ...Option) *connector {
+	transport := &http.Transport{
+		ReadBufferSize: 128 * 1024,
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+		}).DialContext,
+	}
+
+	c := &connector{
+		auth:      auth,
+		baseURL:   baseURL,
+		transport: transport,
+		retry:     defaultRetryPolicy(),
+		http: &http.Client{
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// URL creates a request builder for the endpoint. It is either an absolute
+// URL or a path relative to the base URL.
+func (c *connector) URL(templatePath string, args ...interface{}) restapi.CURL {
+	target := fmt.Sprintf(templatePath, args...)
+	if strings.HasPrefix(target, "/") {
+		target = c.baseURL + target
+	}
+
+	return &curl{
+		connector: c,
+		url:       target,
+		header:    http.Header{},
+	}
+}
+
+// do sends the request, and sends it again as long as it fails with a
+// transient error and the retry policy allows it.
+func (c *connector) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req)
+		if attempt >= c.retry.maxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send sends the request once. A request rejected with 401 is sent again
+// with a new access token, as the current one may have been revoked or
+// expired early.
+func (c *connector) send(req *http.Request) (*http.Response, error) {
+	token, err := c.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.roundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	auth, ok := c.auth.(refresher)
+	if !ok || req.GetBody == nil {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	auth.invalidate(token)
+
+	if req, err = rewind(req); err != nil {
+		return nil, err
+	}
+	if _, err := c.authorize(req); err != nil {
+		return nil, err
+	}
+	return c.roundTrip(req)
+}
+
+// rewind returns a copy of the request that can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("cannot send %s %s again", req.Method, req.URL.Redacted())
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, nil
+}
+
+// authorize sets the authentication headers of the request and returns the
+// access token used.
+func (c *connector) authorize(req *http.Request) (string, error) {
+	req.Header.Set("User-Agent", restapi.UserAgent)
+	if c.auth == nil {
+		return "", nil
+	}
+
+	token, err := c.auth.AccessToken()
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", token)
+
+	if cookie := c.auth.Cookie(); cookie != "" {
+		req.Header.Set("Cookie", cookie)
+	}
+	return token, nil
+}
+
+// curl builds and executes a single HTTP request.
+type curl struct {
+	connector *connector
+	method    string
+	url       string
+	header    http.Header
+	payload   []byte
+	fail      error
+}
+
+// Query defines URI parameters of the request.
+func (r *curl) Query(data interface{}) restapi.CURL {
+	if r.fail != nil {
+		return r
+	}
+
+	params, err := encodeValues(data)
+	if r.fail = err; err != nil {
+		return r
+	}
+	r.url = r.url + "?" + params.Encode()
+	return r
+}
+
+// Header defines a request header.
+func (r *curl) Header(head, value string) restapi.CURL {
+	r.header.Add(head, value)
+	return r
+}
+
+// Status executes a GET request and discards the response body.
+func (r *curl) Status(status ...int) (http.Header, error) {
+	r.method = http.MethodGet
+	header, _, err := r.exec(status...)
+	return header, err
+}
+
+// Get fetches content from the endpoint.
+func (r *curl) Get(in interface{}) (http.Header, error) {
+	r.method = http.MethodGet
+	return r.recv(in)
+}
+
+// Put sends content to the endpoint.
+func (r *curl) Put(eg interface{}, in ...interface{}) (http.Header, error) {
+	r.method = http.MethodPut
+	r.send(eg)
+
+	if len(in) > 0 {
+		return r.recv(in[0])
+	}
+	header, _, err := r.exec()
+	return header, err
+}
+
+// Post sends content to the endpoint.
+func (r *curl) Post(eg interface{}, in ...interface{}) (http.Header, error) {
+	r.method = http.MethodPost
+
+	if eg != nil {
+		r.send(eg)
+	}
+
+	if len(in) > 0 {
+		return r.recv(in[0])
+	}
+	header, _, err := r.exec()
+	return header, err
+}
+
+// Delete removes the content behind the URL.
+func (r *curl) Delete(in ...interface{}) (http.Header, error) {
+	r.method = http.MethodDelete
+
+	if len(in) > 0 {
+		return r.recv(in[0])
+	}
+	header, _, err := r.exec()
+	return header, err
+}
+
+// Fetch receives the raw content of the endpoint.
+func (r *curl) Fetch() ([]byte, error) {
+	r.method = http.MethodGet
+	_, body, err := r.exec()
+	return body, err
+}
+
+// Download saves the content of the endpoint into a file.
+func (r *curl) Download(filename string) error {
+	body, err := r.Fetch()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, body, 0600)
+}
+
+func (r *curl) send(data interface{}) {
+	if r.fail != nil {
+		return
+	}
+
+	if r.header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		params, err := encodeValues(data)
+		if r.fail = err; err == nil {
+			r.payload = []byte(params.Encode())
+		}
+		return
+	}
+
+	r.header.Set("Content-Type", "application/json")
+	r.payload, r.fail = json.Marshal(data)
+}
+
+func (r *curl) recv(data interface{}) (http.Header, error) {
+	header, body, err := r.exec()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// exec runs the request and returns the response headers and body. Any
+// unexpected status is turned into an *APIError.
+func (r *curl) exec(status ...int) (http.Header, []byte, error) {
+	if r.fail != nil {
+		return nil, nil, r.fail
+	}
+
+	req, err := http.NewRequest(r.method, r.url, bytes.NewReader(r.payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	for head := range r.header {
+		req.Header.Set(head, r.header.Get(head))
+	}
+
+	resp, err := r.connector.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if (len(status) == 1 && resp.StatusCode != status[0]) || resp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, newAPIError(resp, body)
+	}
+
+	return resp.Header, body, nil
+}
+
+// encodeValues flattens a JSON-serializable struct into URL values, the same
+// way the PrivX SDK does.
+func encodeValues(query interface{}) (url.Values, error) {
+	bin, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var params map[string]interface{}
+	if err = json.Unmarshal(bin, &params); err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	for key, param := range params {
+		switch v := param.(type) {
+		case float64:
+			values.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+		case string:
+			values.Set(key, v)
+		case bool:
+			values.Set(key, strconv.FormatBool(v))
+		default:
+			return nil, fmt.Errorf("wrong format: %T", v)
+		}
+	}
+
+	return values, nil
+}
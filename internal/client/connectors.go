@@ -0,0 +1,76 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+)
+
+// Connectors is a registry of connectors to PrivX endpoints, by name. The
+// connectors are created, and authenticated, on first use, so that an
+// unreachable endpoint only fails the resources using it.
+type Connectors struct {
+	defaultEndpoint string
+	endpoints       map[string]*endpoint
+}
+
+type endpoint struct {
+	once      sync.Once
+	connect   func() (*restapi.Connector, error)
+	connector *restapi.Connector
+	err       error
+}
+
+// NewConnectors returns an empty registry. Connectors requested without a
+// name are those of defaultEndpoint, or of the endpoint added without a name
+// if it is empty.
+func NewConnectors(defaultEndpoint string) *Connectors {
+	return &Connectors{
+		defaultEndpoint: defaultEndpoint,
+		endpoints:       map[string]*endpoint{},
+	}
+}
+
+// Add registers the endpoint of that name. connect is called once, the
+// first time the connector of the endpoint is requested.
+func (c *Connectors) Add(name string, connect func() (*restapi.Connector, error)) {
+	c.endpoints[name] = &endpoint{connect: connect}
+}
+
+// Has reports whether an endpoint of that name is registered.
+func (c *Connectors) Has(name string) bool {
+	_, ok := c.endpoints[name]
+	return ok
+}
+
+// Names returns the names of the registered endpoints, sorted.
+func (c *Connectors) Names() []string {
+	names := make([]string, 0, len(c.endpoints))
+	for name := range c.endpoints {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Connector returns the connector of the named endpoint, or of the default
+// one if name is empty.
+func (c *Connectors) Connector(name string) (*restapi.Connector, error) {
+	if name == "" {
+		name = c.defaultEndpoint
+	}
+
+	e, ok := c.endpoints[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown PrivX endpoint %q", name)
+	}
+
+	e.once.Do(func() {
+		e.connector, e.err = e.connect()
+	})
+	return e.connector, e.err
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+)
+
+// APIError is returned by the connector when PrivX answers with an
+// unexpected HTTP status.
+type APIError struct {
+	StatusCode int
+	Err        error
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Err:        restapi.ErrorFromResponse(resp, body),
+	}
+}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err means the requested PrivX object does not
+// exist (anymore).
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
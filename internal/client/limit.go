@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// limiter caps the rate and the concurrency of the requests sent to PrivX.
+// A nil limiter does not limit anything.
+type limiter struct {
+	rate  *rate.Limiter
+	slots chan struct{}
+}
+
+// WithRateLimit limits the requests to requestsPerSecond, with at most
+// maxConcurrent of them in flight. A limit of 0 disables it. The limits are
+// shared by every request of the connector, including retries and
+// authentication.
+func WithRateLimit(requestsPerSecond float64, maxConcurrent int) Option {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return func(c *connector) {}
+	}
+
+	// The same limiter is shared by all the connectors the option is
+	// applied to
+	l := &limiter{}
+	if requestsPerSecond > 0 {
+		l.rate = rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+	}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	return func(c *connector) {
+		c.limit = l
+	}
+}
+
+// acquire waits until a request can be sent. release must be called once
+// it completed.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// roundTrip sends the request within the limits of the connector. The
+// request counts against the concurrency limit until its response body is
+// closed.
+func (c *connector) roundTrip(req *http.Request) (*http.Response, error) {
+	release, err := c.limit.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	do := c.http.Do
+	if c.tracer != nil {
+		do = c.trace
+	}
+
+	resp, err := do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseBody releases the limiter slot of a request once its response body
+// is closed.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
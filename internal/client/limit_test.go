@@ -0,0 +1,68 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := newConnector(server.URL, nil, WithRateLimit(0, 2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var result interface{}
+			if _, err := c.URL("/").Get(&result); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func TestRateLimitRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := newConnector(server.URL, nil, WithRateLimit(20, 0))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		var result interface{}
+		if _, err := c.URL("/").Get(&result); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The first request is sent right away, the next ones every 50ms
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("expected 5 requests at 20 per second to take 200ms, took %s", elapsed)
+	}
+}
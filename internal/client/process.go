@@ -0,0 +1,64 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// credentialProcessTimeout is how long a credential process may run.
+const credentialProcessTimeout = time.Minute
+
+// Credentials authenticate to the PrivX API, either with a bearer token or
+// with the API client and OAuth client credentials of the password grant.
+type Credentials struct {
+	APIClientID       string `json:"api_client_id"`
+	APIClientSecret   string `json:"api_client_secret"`
+	OAuthClientID     string `json:"oauth_client_id"`
+	OAuthClientSecret string `json:"oauth_client_secret"`
+	BearerToken       string `json:"bearer_token"`
+}
+
+// CredentialProcess returns the credentials printed by the command, as a
+// JSON object with the keys of Credentials. The command is run by the shell
+// each time the credentials are requested.
+func CredentialProcess(command string) func() (Credentials, error) {
+	return func() (Credentials, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), credentialProcessTimeout)
+		defer cancel()
+
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+		} else {
+			cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return Credentials{}, fmt.Errorf("credential process failed: %v: %s", err, msg)
+			}
+			return Credentials{}, fmt.Errorf("credential process failed: %v", err)
+		}
+
+		// The output holds secrets, so it is not part of the errors
+		var credentials Credentials
+		if err := json.Unmarshal(stdout.Bytes(), &credentials); err != nil {
+			return Credentials{}, fmt.Errorf("credential process output is not a JSON object of credentials")
+		}
+		if credentials.BearerToken == "" &&
+			(credentials.APIClientID == "" || credentials.APIClientSecret == "" ||
+				credentials.OAuthClientID == "" || credentials.OAuthClientSecret == "") {
+			return Credentials{}, fmt.Errorf("credential process output holds neither bearer_token, nor api_client_id, api_client_secret, oauth_client_id and oauth_client_secret")
+		}
+		return credentials, nil
+	}
+}
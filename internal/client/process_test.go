@@ -0,0 +1,147 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-privx/internal/privxmock"
+)
+
+// stubCredentialProcess writes a script printing the credentials, which
+// counts its runs in a file, and returns the command running it.
+func stubCredentialProcess(t *testing.T, credentials Credentials) (command string, runs func() int) {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the stub credential process is a shell script")
+	}
+
+	dir := t.TempDir()
+	output, err := json.Marshal(credentials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "credentials.json"), output, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	script := filepath.Join(dir, "credential-process")
+	counter := filepath.Join(dir, "runs")
+	content := fmt.Sprintf("#!/bin/sh\necho run >> %q\ncat %q\n", counter, filepath.Join(dir, "credentials.json"))
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	return script, func() int {
+		content, err := os.ReadFile(counter)
+		if err != nil {
+			return 0
+		}
+		return strings.Count(string(content), "run")
+	}
+}
+
+func TestCredentialProcessRunAgainForNewToken(t *testing.T) {
+	server := privxmock.New()
+	defer server.Close()
+
+	command, runs := stubCredentialProcess(t, Credentials{
+		APIClientID:       privxmock.APIClientID,
+		APIClientSecret:   privxmock.APIClientSecret,
+		OAuthClientID:     privxmock.OAuthClientID,
+		OAuthClientSecret: privxmock.OAuthClientSecret,
+	})
+
+	c, err := NewCredentialProcessConnector(server.URL, command)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := listAccessGroups(*c); err != nil {
+		t.Fatal(err)
+	}
+	if n := runs(); n != 1 {
+		t.Errorf("expected 1 run of the credential process, got %d", n)
+	}
+
+	// A revoked token is replaced with new credentials
+	server.RevokeTokens()
+	if err := listAccessGroups(*c); err != nil {
+		t.Fatal(err)
+	}
+	if n := runs(); n != 2 {
+		t.Errorf("expected 2 runs of the credential process, got %d", n)
+	}
+
+	// So is an expired one
+	auth := (*c).(*connector).auth.(*passwordAuthorizer) //nolint:forcetypeassert
+	now := time.Now()
+	auth.now = func() time.Time { return now.Add(time.Hour) }
+	if err := listAccessGroups(*c); err != nil {
+		t.Fatal(err)
+	}
+	if n := runs(); n != 3 {
+		t.Errorf("expected 3 runs of the credential process, got %d", n)
+	}
+}
+
+func TestCredentialProcessBearerToken(t *testing.T) {
+	server := privxmock.New()
+	defer server.Close()
+
+	// The mock only accepts the tokens it granted
+	auth := testConnector(t, server).(*connector).auth //nolint:forcetypeassert
+	token, err := auth.AccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	command, runs := stubCredentialProcess(t, Credentials{
+		BearerToken: strings.TrimPrefix(token, "Bearer "),
+	})
+
+	c, err := NewCredentialProcessConnector(server.URL, command)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := listAccessGroups(*c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := runs(); n != 1 {
+		t.Errorf("expected 1 run of the credential process, got %d", n)
+	}
+	if grants := server.TokenGrants(); grants != 1 {
+		t.Errorf("expected 1 token grant, got %d", grants)
+	}
+}
+
+func TestCredentialProcessErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the stub credential processes are shell commands")
+	}
+
+	for _, tc := range []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"failure", "echo locked >&2; exit 1", "credential process failed: exit status 1: locked"},
+		{"invalid output", "echo not json", "not a JSON object"},
+		{"incomplete credentials", `echo '{"api_client_id": "client"}'`, "holds neither bearer_token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CredentialProcess(tc.command)()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ParseProxyURL parses the URL of an HTTP(S) or SOCKS5 proxy. Proxy
+// credentials are given as the user info of the URL.
+func ParseProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing proxy host in %q", u.Redacted())
+	}
+	return u, nil
+}
+
+// WithProxy sends the requests through the proxy, except those to the hosts
+// matching noProxy. noProxy is a comma separated list of host names, domain
+// suffixes, IP addresses and CIDR ranges, like the NO_PROXY environment
+// variable.
+func WithProxy(proxyURL *url.URL, noProxy string) Option {
+	config := httpproxy.Config{
+		HTTPProxy:  proxyURL.String(),
+		HTTPSProxy: proxyURL.String(),
+		NoProxy:    noProxy,
+	}
+	proxy := config.ProxyFunc()
+
+	return func(c *connector) {
+		c.transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy(req.URL)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Defaults of the retry policy, used unless overridden with WithRetry.
+const (
+	DefaultMaxRetries   = 4
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// retryPolicy defines how often and how long transient PrivX API errors are
+// retried.
+type retryPolicy struct {
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		maxRetries: DefaultMaxRetries,
+		minWait:    500 * time.Millisecond,
+		maxWait:    DefaultRetryMaxWait,
+	}
+}
+
+// WithRetry sets how many times, and how long at most between attempts,
+// transient PrivX API errors are retried. No request is retried with a
+// maxRetries of 0.
+func WithRetry(maxRetries int, maxWait time.Duration) Option {
+	return func(c *connector) {
+		c.retry.maxRetries = maxRetries
+		c.retry.maxWait = maxWait
+		c.retry.minWait = min(c.retry.minWait, maxWait)
+	}
+}
+
+// retryable reports whether a request that failed with either the response
+// or the error is worth sending again: on server errors, throttling,
+// connection resets and timeouts.
+//
+// Requests that are not idempotent, such as the POSTs creating objects, may
+// have been carried out by PrivX before failing. They are only sent again
+// when throttled, or when the connection could not be established.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		var opErr *net.OpError
+		if err != nil {
+			return errors.As(err, &opErr) && opErr.Op == "dial"
+		}
+		return resp.StatusCode == http.StatusTooManyRequests
+	}
+
+	if err != nil {
+		var netErr net.Error
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			(errors.As(err, &netErr) && netErr.Timeout())
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns how long to wait before the next attempt. The Retry-After
+// header of the response is honored, otherwise the wait grows exponentially
+// with the attempts. Both are capped to maxWait.
+func (p retryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return min(wait, p.maxWait)
+	}
+
+	wait := p.maxWait
+	if attempt < 32 {
+		wait = min(p.minWait<<attempt, p.maxWait)
+	}
+
+	// Jitter spreads the retries of the parallel resource operations
+	jitter := time.Duration(rand.Int63n(int64(wait/2) + 1))
+	return wait/2 + jitter
+}
+
+// retryAfter parses the Retry-After header, either a number of seconds or
+// an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// sleep waits for the duration, unless the context is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
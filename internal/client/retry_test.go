@@ -0,0 +1,141 @@
+package client
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// flakyServer answers the first failures requests with status, then 200.
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"count":0}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testRetryConnector(baseURL string, maxRetries int) *connector {
+	c := newConnector(baseURL, nil, WithRetry(maxRetries, time.Second))
+	c.retry.minWait = time.Millisecond
+	return c
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusTooManyRequests} {
+		server, requests := flakyServer(t, 2, status)
+
+		var result interface{}
+		if _, err := testRetryConnector(server.URL, 3).URL("/").Put(map[string]string{"name": "test"}, &result); err != nil {
+			t.Fatalf("status %d: %s", status, err)
+		}
+		if got := atomic.LoadInt32(requests); got != 3 {
+			t.Errorf("status %d: expected 3 requests, got %d", status, got)
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, requests := flakyServer(t, 10, http.StatusBadGateway)
+
+	var result interface{}
+	if _, err := testRetryConnector(server.URL, 2).URL("/").Get(&result); err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRetryClientErrorsNotRetried(t *testing.T) {
+	server, requests := flakyServer(t, 1, http.StatusBadRequest)
+
+	var result interface{}
+	if _, err := testRetryConnector(server.URL, 3).URL("/").Get(&result); err == nil {
+		t.Fatal("expected a client error")
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestRetryPostOnlyWhenThrottled(t *testing.T) {
+	server, requests := flakyServer(t, 1, http.StatusServiceUnavailable)
+
+	var result interface{}
+	if _, err := testRetryConnector(server.URL, 3).URL("/").Post(map[string]string{"name": "test"}, &result); err == nil {
+		t.Fatal("expected a server error")
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+
+	server, requests = flakyServer(t, 2, http.StatusTooManyRequests)
+	if _, err := testRetryConnector(server.URL, 3).URL("/").Post(map[string]string{"name": "test"}, &result); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRetryableErrors(t *testing.T) {
+	dial := &url.Error{Op: "Post", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	reset := &url.Error{Op: "Post", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}
+
+	for _, tt := range []struct {
+		method string
+		err    error
+		want   bool
+	}{
+		{http.MethodGet, reset, true},
+		{http.MethodDelete, io.EOF, true},
+		{http.MethodPost, dial, true},
+		{http.MethodPost, reset, false},
+		{http.MethodPost, io.EOF, false},
+	} {
+		req := httptest.NewRequest(tt.method, "/", nil)
+		if got := retryable(req, nil, tt.err); got != tt.want {
+			t.Errorf("%s %s: expected retryable %t, got %t", tt.method, tt.err, tt.want, got)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := retryPolicy{maxRetries: 5, minWait: time.Second, maxWait: 10 * time.Second}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second} {
+		got := policy.backoff(attempt, nil)
+		if got < want/2 || got > want {
+			t.Errorf("attempt %d: expected a wait between %s and %s, got %s", attempt, want/2, want, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := policy.backoff(0, resp); got != 3*time.Second {
+		t.Errorf("expected Retry-After to be honored, got %s", got)
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if got := policy.backoff(0, resp); got != policy.maxWait {
+		t.Errorf("expected Retry-After to be capped to %s, got %s", policy.maxWait, got)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(5*time.Second).UTC().Format(http.TimeFormat))
+	if got := policy.backoff(0, resp); got <= 3*time.Second || got > 5*time.Second {
+		t.Errorf("expected Retry-After date to be honored, got %s", got)
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// TLSOptions configures how the PrivX server certificate is verified and
+// how the connector authenticates to it.
+type TLSOptions struct {
+	// CACertificate is a PEM bundle of CA certificates trusted in addition
+	// to the system ones.
+	CACertificate []byte
+	// ClientCertificate and ClientKey are a PEM certificate and key used for
+	// mutual TLS.
+	ClientCertificate []byte
+	ClientKey         []byte
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// Config returns the TLS configuration of the options.
+func (o TLSOptions) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify, //nolint:gosec // explicitly requested
+	}
+
+	if len(o.CACertificate) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(o.CACertificate) {
+			return nil, fmt.Errorf("no PEM certificate found in the CA certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	if len(o.ClientCertificate) > 0 || len(o.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// WithTLS configures the TLS connections to PrivX.
+func WithTLS(config *tls.Config) Option {
+	return func(c *connector) {
+		c.transport.TLSClientConfig = config
+	}
+}
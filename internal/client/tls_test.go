@@ -0,0 +1,121 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testClientCertificate returns a self-signed PEM certificate and key.
+func testClientCertificate(t *testing.T) (*x509.Certificate, []byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func testTLSGet(t *testing.T, url string, o TLSOptions) error {
+	t.Helper()
+
+	config, err := o.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result interface{}
+	_, err = newConnector(url, nil, WithRetry(0, 0), WithTLS(config)).URL("/").Get(&result)
+	return err
+}
+
+func TestTLSCACertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	if err := testTLSGet(t, server.URL, TLSOptions{}); err == nil {
+		t.Error("expected the server certificate to be untrusted")
+	}
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := testTLSGet(t, server.URL, TLSOptions{CACertificate: ca}); err != nil {
+		t.Error(err)
+	}
+
+	if err := testTLSGet(t, server.URL, TLSOptions{InsecureSkipVerify: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+	cert, certPEM, keyPEM := testClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	if err := testTLSGet(t, server.URL, TLSOptions{InsecureSkipVerify: true}); err == nil {
+		t.Error("expected the server to require a client certificate")
+	}
+
+	o := TLSOptions{InsecureSkipVerify: true, ClientCertificate: certPEM, ClientKey: keyPEM}
+	if err := testTLSGet(t, server.URL, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTLSOptionsInvalid(t *testing.T) {
+	_, certPEM, _ := testClientCertificate(t)
+
+	for name, o := range map[string]TLSOptions{
+		"ca":         {CACertificate: []byte("not a certificate")},
+		"client key": {ClientCertificate: certPEM},
+	} {
+		if _, err := o.Config(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"terraform-provider-privx/internal/utils"
+)
+
+// maxTracedBody is the size above which traced bodies are truncated.
+const maxTracedBody = 16 * 1024
+
+// Tracer receives a trace of each HTTP request sent to PrivX. Secrets are
+// redacted from the fields.
+type Tracer func(msg string, fields map[string]interface{})
+
+// WithTracer traces the HTTP requests sent to PrivX, including their
+// redacted bodies.
+func WithTracer(tracer Tracer) Option {
+	return func(c *connector) {
+		c.tracer = tracer
+	}
+}
+
+// trace sends the request and traces it, with its response or error.
+func (c *connector) trace(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.Redacted(),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			payload, _ := io.ReadAll(body)
+			fields["request_body"] = tracedBody(payload, req.Header.Get("Content-Type"))
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		c.tracer("PrivX API request failed", fields)
+		return nil, err
+	}
+
+	// The body is read here to be traced, and replayed to the caller
+	payload, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(payload))
+
+	fields["status"] = resp.StatusCode
+	fields["response_body"] = tracedBody(payload, resp.Header.Get("Content-Type"))
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	c.tracer("PrivX API request", fields)
+
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func tracedBody(body []byte, contentType string) string {
+	redacted := utils.RedactBody(body, contentType)
+	if len(redacted) > maxTracedBody {
+		return redacted[:maxTracedBody] + "...(truncated)"
+	}
+	return redacted
+}
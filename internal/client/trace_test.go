@@ -0,0 +1,57 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"secret-id","secret":"hidden"}`))
+	}))
+	defer server.Close()
+
+	var traces []map[string]interface{}
+	c := newConnector(server.URL, nil, WithTracer(func(msg string, fields map[string]interface{}) {
+		traces = append(traces, fields)
+	}))
+
+	var result struct {
+		Secret string `json:"secret"`
+	}
+	if _, err := c.URL("/vault/api/v1/secrets").Post(map[string]interface{}{"name": "db", "data": "hidden"}, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	// The caller still receives the traced response
+	if result.Secret != "hidden" {
+		t.Errorf("expected the response body to be replayed, got %+v", result)
+	}
+
+	if len(traces) != 1 {
+		t.Fatalf("expected 1 trace, got %d", len(traces))
+	}
+	trace := traces[0]
+	for field, want := range map[string]interface{}{
+		"method":        http.MethodPost,
+		"url":           server.URL + "/vault/api/v1/secrets",
+		"status":        http.StatusOK,
+		"request_body":  `{"data":"***","name":"db"}`,
+		"response_body": `{"id":"secret-id","secret":"***"}`,
+	} {
+		if trace[field] != want {
+			t.Errorf("expected %s %v, got %v", field, want, trace[field])
+		}
+	}
+	if _, ok := trace["latency_ms"]; !ok {
+		t.Error("expected the latency to be traced")
+	}
+	for _, v := range trace {
+		if s, ok := v.(string); ok && strings.Contains(s, "hidden") {
+			t.Errorf("expected secrets to be redacted, got %s", s)
+		}
+	}
+}
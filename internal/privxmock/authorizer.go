@@ -0,0 +1,85 @@
+package privxmock
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultAccessGroupID is the ID of the default access group every PrivX
+// deployment comes with.
+const DefaultAccessGroupID = "00000000-0000-4000-8000-000000000000"
+
+// certTemplates are the certificate templates PrivX comes with.
+var certTemplates = []object{
+	{"name": "ssh-host", "description": "SSH host certificate", "service": "SSH", "type": "HOST"},
+	{"name": "ssh-user", "description": "SSH user certificate", "service": "SSH", "type": "USER"},
+	{"name": "rdp-host", "description": "RDP host certificate", "service": "RDP", "type": "HOST"},
+}
+
+func (s *Server) registerAuthorizer() {
+	accessGroups := s.collection("access_groups", "id", "ca_id", "default")
+	accessGroups.put(object{
+		"id":      DefaultAccessGroupID,
+		"name":    "Default",
+		"comment": "Default access group",
+		"ca_id":   DefaultAccessGroupID,
+		"default": true,
+		"created": now(),
+		"author":  APIClientID,
+	})
+
+	s.crud("/authorizer/api/v1/accessgroups", accessGroups, hooks{
+		create: func(obj object) string {
+			if name, _ := obj["name"].(string); name == "" {
+				return "MISSING_NAME"
+			}
+			obj["ca_id"] = s.newID()
+			obj["default"] = false
+			return ""
+		},
+	})
+
+	s.handle(http.MethodGet, "/authorizer/api/v1/cert/templates", func(w http.ResponseWriter, r *http.Request, _ []string) {
+		templates := []object{}
+		for _, template := range certTemplates {
+			if service := r.URL.Query().Get("service"); service == "" || service == template["service"] {
+				templates = append(templates, template)
+			}
+		}
+		writeJSON(w, http.StatusOK, object{"count": len(templates), "items": templates})
+	})
+
+	for _, kind := range []string{"carrier", "extender", "icap"} {
+		s.registerConfigDownload(kind)
+	}
+}
+
+// registerConfigDownload registers the two step download of the
+// configuration of a trusted client: a session is requested first, then the
+// configuration is fetched with it.
+func (s *Server) registerConfigDownload(kind string) {
+	trustedClients := s.collection("trusted_clients", "id")
+	path := "/authorizer/api/v1/" + kind + "/conf/([^/]+)"
+
+	s.handle(http.MethodPost, path, func(w http.ResponseWriter, r *http.Request, params []string) {
+		if _, ok := trustedClients.get(params[0]); !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+
+		sessionID := s.newID()
+		s.sessions[sessionID] = params[0]
+		writeJSON(w, http.StatusOK, object{"session_id": sessionID})
+	})
+
+	s.handle(http.MethodGet, path+"/([^/]+)", func(w http.ResponseWriter, r *http.Request, params []string) {
+		if s.sessions[params[1]] != params[0] {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		delete(s.sessions, params[1])
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprintf(w, "# PrivX %s configuration\ntrusted_client_id = %q\n", kind, params[0])
+	})
+}
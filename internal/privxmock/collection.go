@@ -0,0 +1,187 @@
+package privxmock
+
+import (
+	"net/http"
+	"strconv"
+)
+
+type object = map[string]interface{}
+
+// collection is an in-memory, insertion ordered, set of PrivX objects.
+type collection struct {
+	key      string
+	readOnly []string
+	order    []string
+	items    map[string]object
+}
+
+// collection returns the named collection, creating it on first use. Objects
+// are identified by their key attribute, readOnly attributes are managed by
+// the server and never overwritten by updates.
+func (s *Server) collection(name, key string, readOnly ...string) *collection {
+	c, ok := s.collections[name]
+	if !ok {
+		c = &collection{
+			key:      key,
+			readOnly: readOnly,
+			items:    map[string]object{},
+		}
+		s.collections[name] = c
+	}
+	return c
+}
+
+func (c *collection) get(id string) (object, bool) {
+	obj, ok := c.items[id]
+	return obj, ok
+}
+
+func (c *collection) put(obj object) {
+	id, _ := obj[c.key].(string)
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = obj
+}
+
+func (c *collection) remove(id string) {
+	delete(c.items, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *collection) list() []object {
+	objs := make([]object, 0, len(c.order))
+	for _, id := range c.order {
+		objs = append(objs, c.items[id])
+	}
+	return objs
+}
+
+// hooks customize the generic CRUD endpoints of a collection. create and
+// update may fill server side attributes or reject the object by returning
+// an error code.
+type hooks struct {
+	create func(obj object) string
+	update func(obj object) string
+	remove func(obj object)
+}
+
+// crud registers the create, list, get, update and delete endpoints of c
+// under path.
+func (s *Server) crud(path string, c *collection, h hooks) {
+	s.handle(http.MethodPost, path, func(w http.ResponseWriter, r *http.Request, _ []string) {
+		var obj object
+		if err := readJSON(r, &obj); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON")
+			return
+		}
+
+		if c.key == "id" {
+			obj["id"] = s.newID()
+		}
+		id, _ := obj[c.key].(string)
+		if id == "" {
+			writeError(w, http.StatusBadRequest, "MISSING_"+c.key)
+			return
+		}
+		if _, ok := c.get(id); ok {
+			writeError(w, http.StatusConflict, "OBJECT_ALREADY_EXISTS")
+			return
+		}
+
+		obj["created"] = now()
+		obj["updated"] = obj["created"]
+		obj["author"] = APIClientID
+		obj["updated_by"] = APIClientID
+		if h.create != nil {
+			if code := h.create(obj); code != "" {
+				writeError(w, http.StatusBadRequest, code)
+				return
+			}
+		}
+		c.put(obj)
+
+		writeJSON(w, http.StatusCreated, object{c.key: id})
+	})
+
+	s.handle(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request, _ []string) {
+		writeJSON(w, http.StatusOK, page(r, c.list()))
+	})
+
+	s.handle(http.MethodGet, path+"/([^/]+)", func(w http.ResponseWriter, r *http.Request, params []string) {
+		obj, ok := c.get(params[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+	})
+
+	s.handle(http.MethodPut, path+"/([^/]+)", func(w http.ResponseWriter, r *http.Request, params []string) {
+		current, ok := c.get(params[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+
+		var obj object
+		if err := readJSON(r, &obj); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON")
+			return
+		}
+
+		for _, attr := range append([]string{c.key, "created", "author"}, c.readOnly...) {
+			if v, ok := current[attr]; ok {
+				obj[attr] = v
+			} else {
+				delete(obj, attr)
+			}
+		}
+		obj["updated"] = now()
+		obj["updated_by"] = APIClientID
+		if h.update != nil {
+			if code := h.update(obj); code != "" {
+				writeError(w, http.StatusBadRequest, code)
+				return
+			}
+		}
+		c.put(obj)
+
+		writeJSON(w, http.StatusOK, object{})
+	})
+
+	s.handle(http.MethodDelete, path+"/([^/]+)", func(w http.ResponseWriter, r *http.Request, params []string) {
+		obj, ok := c.get(params[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		c.remove(params[0])
+		if h.remove != nil {
+			h.remove(obj)
+		}
+
+		writeJSON(w, http.StatusOK, object{})
+	})
+}
+
+// page applies the offset and limit query parameters of r to objs, and wraps
+// the result the way PrivX list endpoints do.
+func page(r *http.Request, objs []object) object {
+	count := len(objs)
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	offset = min(max(offset, 0), count)
+	objs = objs[offset:]
+
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit >= 0 && limit < len(objs) {
+		objs = objs[:limit]
+	}
+
+	return object{"count": count, "items": objs}
+}
@@ -0,0 +1,243 @@
+package privxmock
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// hostSearchFields maps the list filters of the host search request to the
+// host attribute they are matched against.
+var hostSearchFields = map[string]string{
+	"common_name":            "common_name",
+	"address":                "addresses",
+	"zone":                   "zone",
+	"host_type":              "host_type",
+	"host_classification":    "host_classification",
+	"tags":                   "tags",
+	"access_group_ids":       "access_group_id",
+	"cloud_providers":        "cloud_provider",
+	"cloud_provider_regions": "cloud_provider_region",
+	"organization":           "organization",
+	"organizational_unit":    "organizational_unit",
+}
+
+func (s *Server) registerHostStore() {
+	hosts := s.collection("hosts", "id")
+
+	s.handle(http.MethodPost, "/host-store/api/v1/hosts/search", func(w http.ResponseWriter, r *http.Request, _ []string) {
+		var search object
+		if err := readJSON(r, &search); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON")
+			return
+		}
+
+		var found []object
+		for _, host := range hosts.list() {
+			if hostMatches(host, search) {
+				found = append(found, host)
+			}
+		}
+		writeJSON(w, http.StatusOK, page(r, found))
+	})
+
+	s.crud("/host-store/api/v1/hosts", hosts, hooks{
+		create: defaultHost,
+		update: defaultHost,
+	})
+
+	whitelists := s.collection("whitelists", "id")
+
+	s.handle(http.MethodPost, "/host-store/api/v1/whitelists/evaluate", func(w http.ResponseWriter, r *http.Request, _ []string) {
+		var evaluation struct {
+			Whitelist     object   `json:"whitelist"`
+			RShellVariant string   `json:"rshell_variant"`
+			Commands      []string `json:"commands"`
+		}
+		if err := readJSON(r, &evaluation); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON")
+			return
+		}
+		if evaluation.Whitelist == nil {
+			writeError(w, http.StatusBadRequest, "MISSING_WHITELIST")
+			return
+		}
+		if code := validateWhitelistPatterns(evaluation.Whitelist); code != "" {
+			writeError(w, http.StatusBadRequest, code)
+			return
+		}
+		if evaluation.RShellVariant != "bash" && evaluation.RShellVariant != "posix" {
+			writeError(w, http.StatusBadRequest, "INVALID_RSHELL_VARIANT")
+			return
+		}
+
+		patternResults := []object{}
+		for _, pattern := range stringList(evaluation.Whitelist["whitelist_patterns"]) {
+			status := []object{}
+			if _, err := whitelistPatternMatches(evaluation.Whitelist["type"].(string), pattern, ""); err != nil {
+				status = append(status, object{"k": "INVALID_PATTERN", "v": err.Error()})
+			}
+			patternResults = append(patternResults, object{"whitelist_pattern": pattern, "status": status})
+		}
+
+		commandResults := []object{}
+		for _, command := range evaluation.Commands {
+			allowed := false
+			for _, pattern := range stringList(evaluation.Whitelist["whitelist_patterns"]) {
+				if ok, _ := whitelistPatternMatches(evaluation.Whitelist["type"].(string), pattern, command); ok {
+					allowed = true
+					break
+				}
+			}
+			commandResults = append(commandResults, object{"command": command, "allowed": allowed, "status": []object{}})
+		}
+
+		writeJSON(w, http.StatusOK, object{
+			"whitelist_pattern_results": patternResults,
+			"command_results":           commandResults,
+		})
+	})
+
+	s.crud("/host-store/api/v1/whitelists", whitelists, hooks{
+		create: validateWhitelist,
+		update: validateWhitelist,
+	})
+}
+
+// validateWhitelist rejects the whitelists PrivX would.
+func validateWhitelist(obj object) string {
+	if name, _ := obj["name"].(string); name == "" {
+		return "MISSING_NAME"
+	}
+	return validateWhitelistPatterns(obj)
+}
+
+// validateWhitelistPatterns rejects invalid patterns, defaulting the pattern
+// type to GLOB.
+func validateWhitelistPatterns(obj object) string {
+	if obj["type"] == nil || obj["type"] == "" {
+		obj["type"] = "GLOB"
+	}
+	if obj["type"] != "GLOB" && obj["type"] != "REGEX" {
+		return "INVALID_WHITELIST_TYPE"
+	}
+	for _, pattern := range stringList(obj["whitelist_patterns"]) {
+		if _, err := whitelistPatternMatches(obj["type"].(string), pattern, ""); err != nil {
+			return "INVALID_WHITELIST_PATTERN"
+		}
+	}
+	if obj["whitelist_patterns"] == nil {
+		obj["whitelist_patterns"] = []interface{}{}
+	}
+	return ""
+}
+
+// whitelistPatternMatches approximates the rshell matching of a command:
+// patterns must match the whole command, * and ? of GLOB patterns matching
+// any characters, slashes included.
+func whitelistPatternMatches(patternType, pattern, command string) (bool, error) {
+	if patternType != "REGEX" {
+		pattern = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(pattern))
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(command), nil
+}
+
+func stringList(v interface{}) []string {
+	var values []string
+	list, _ := v.([]interface{})
+	for _, e := range list {
+		if s, ok := e.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// hostLists are the host attributes PrivX returns as empty lists rather than
+// omitting them.
+var hostLists = []string{"scope", "tags", "addresses", "services", "principals", "ssh_host_public_keys"}
+
+func defaultHost(obj object) string {
+	for _, attr := range hostLists {
+		if obj[attr] == nil {
+			obj[attr] = []interface{}{}
+		}
+	}
+	return ""
+}
+
+// hostMatches approximates the PrivX host search: string filters are
+// case-insensitive substring matches, list filters match any value.
+func hostMatches(host, search object) bool {
+	for _, attr := range []string{"id", "external_id", "instance_id", "source_id"} {
+		want, _ := search[attr].(string)
+		if want != "" && !containsFold(host[attr], want) {
+			return false
+		}
+	}
+
+	if keywords, _ := search["keywords"].(string); keywords != "" {
+		found := false
+		for _, v := range host {
+			if containsFold(v, keywords) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for filter, attr := range hostSearchFields {
+		values, _ := search[filter].([]interface{})
+		if len(values) == 0 {
+			continue
+		}
+
+		found := false
+		for _, want := range values {
+			if s, ok := want.(string); ok && equalsAny(host[attr], s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsFold(v interface{}, substr string) bool {
+	switch v := v.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(v), strings.ToLower(substr))
+	case []interface{}:
+		for _, e := range v {
+			if containsFold(e, substr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equalsAny(v interface{}, want string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == want
+	case []interface{}:
+		for _, e := range v {
+			if e == want {
+				return true
+			}
+		}
+	}
+	return false
+}
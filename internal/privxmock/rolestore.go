@@ -0,0 +1,235 @@
+package privxmock
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *Server) registerRoleStore() {
+	roles := s.collection("roles", "id", "principal_public_key_strings", "member_count")
+	keys := s.collection("principal_keys", "id")
+	users := s.collection("local_users", "id")
+	grants := s.collection("role_grants", "id")
+
+	s.handle(http.MethodPost, "/role-store/api/v1/roles/resolve", func(w http.ResponseWriter, r *http.Request, _ []string) {
+		var names []string
+		if err := readJSON(r, &names); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON")
+			return
+		}
+
+		found := []object{}
+		for _, name := range names {
+			for _, role := range roles.list() {
+				if role["name"] == name {
+					found = append(found, object{"id": role["id"], "name": role["name"]})
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, object{"count": len(found), "items": found})
+	})
+
+	s.handle(http.MethodPost, "/role-store/api/v1/roles/([^/]+)/principalkeys/generate", func(w http.ResponseWriter, r *http.Request, params []string) {
+		role, ok := roles.get(params[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+
+		key := object{
+			"id":         s.newID(),
+			"role_id":    params[0],
+			"public_key": fmt.Sprintf("ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQ%012d privx-mock", s.seq),
+		}
+		keys.put(key)
+
+		publicKeys, _ := role["principal_public_key_strings"].([]interface{})
+		role["principal_public_key_strings"] = append(publicKeys, key["public_key"])
+
+		writeJSON(w, http.StatusOK, object{"id": key["id"]})
+	})
+
+	s.handle(http.MethodGet, "/role-store/api/v1/roles/([^/]+)/principalkeys/([^/]+)", func(w http.ResponseWriter, r *http.Request, params []string) {
+		key, ok := keys.get(params[1])
+		if !ok || key["role_id"] != params[0] {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		writeJSON(w, http.StatusOK, object{"id": key["id"], "public_key": key["public_key"]})
+	})
+
+	s.crud("/role-store/api/v1/roles", roles, hooks{
+		create: func(obj object) string {
+			if name, _ := obj["name"].(string); name == "" {
+				return "MISSING_NAME"
+			}
+			obj["principal_public_key_strings"] = []interface{}{}
+			obj["member_count"] = 0
+			if obj["access_group_id"] == nil || obj["access_group_id"] == "" {
+				obj["access_group_id"] = DefaultAccessGroupID
+			}
+			return ""
+		},
+		remove: func(obj object) {
+			for _, grant := range grants.list() {
+				grant["roles"] = withoutRole(grant["roles"], obj["id"])
+			}
+		},
+	})
+
+	s.handle(http.MethodGet, "/role-store/api/v1/roles/([^/]+)/members", func(w http.ResponseWriter, r *http.Request, params []string) {
+		if _, ok := roles.get(params[0]); !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+
+		members := []object{}
+		for _, user := range users.list() {
+			id, _ := user["id"].(string)
+			if grant, ok := grants.get(id); ok && hasRole(grant["roles"], params[0]) {
+				members = append(members, roleStoreUser(user))
+			}
+		}
+		writeJSON(w, http.StatusOK, page(r, members))
+	})
+
+	s.handle(http.MethodGet, "/role-store/api/v1/users/([^/]+)", func(w http.ResponseWriter, r *http.Request, params []string) {
+		user, ok := users.get(params[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		writeJSON(w, http.StatusOK, roleStoreUser(user))
+	})
+
+	s.handle(http.MethodGet, "/role-store/api/v1/users/([^/]+)/roles", func(w http.ResponseWriter, r *http.Request, params []string) {
+		if _, ok := users.get(params[0]); !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+
+		items := []object{}
+		if grant, ok := grants.get(params[0]); ok {
+			granted, _ := grant["roles"].([]object)
+			for _, role := range granted {
+				item := object{"name": ""}
+				for k, v := range role {
+					item[k] = v
+				}
+				if id, _ := role["id"].(string); id != "" {
+					if found, ok := roles.get(id); ok {
+						item["name"] = found["name"]
+					}
+				}
+				items = append(items, item)
+			}
+		}
+		writeJSON(w, http.StatusOK, object{"count": len(items), "items": items})
+	})
+
+	// Only explicit grants are stored, the mock does not evaluate source
+	// rules
+	s.handle(http.MethodPut, "/role-store/api/v1/users/([^/]+)/roles", func(w http.ResponseWriter, r *http.Request, params []string) {
+		if _, ok := users.get(params[0]); !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+
+		var body []object
+		if err := readJSON(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON")
+			return
+		}
+
+		granted := []object{}
+		for _, role := range body {
+			if explicit, _ := role["explicit"].(bool); !explicit {
+				continue
+			}
+			id, _ := role["id"].(string)
+			if _, ok := roles.get(id); !ok {
+				writeError(w, http.StatusBadRequest, "ROLE_NOT_FOUND")
+				return
+			}
+			grantType, _ := role["grant_type"].(string)
+			if grantType == "" {
+				grantType = "PERMANENT"
+			}
+			granted = append(granted, object{
+				"id":          id,
+				"explicit":    true,
+				"grant_type":  grantType,
+				"grant_start": role["grant_start"],
+				"grant_end":   role["grant_end"],
+			})
+		}
+		grants.put(object{"id": params[0], "roles": granted})
+
+		for _, role := range roles.list() {
+			count := 0
+			for _, grant := range grants.list() {
+				if hasRole(grant["roles"], role["id"]) {
+					count++
+				}
+			}
+			role["member_count"] = count
+		}
+		writeJSON(w, http.StatusOK, object{})
+	})
+
+	s.crud("/role-store/api/v1/sources", s.collection("sources", "id"), hooks{})
+}
+
+// roleRefs fills in the names of a list of role references.
+func (s *Server) roleRefs(v interface{}) interface{} {
+	refs, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	roles := s.collection("roles", "id")
+	for _, ref := range refs {
+		ref, ok := ref.(object)
+		if !ok {
+			continue
+		}
+		id, _ := ref["id"].(string)
+		if role, ok := roles.get(id); ok {
+			ref["name"] = role["name"]
+		}
+	}
+	return refs
+}
+
+// roleStoreUser is the role-store view of a local user.
+func roleStoreUser(user object) object {
+	return object{
+		"id":        user["id"],
+		"principal": user["username"],
+		"source":    "local",
+		"full_name": user["full_name"],
+		"email":     user["email"],
+		"tags":      user["tags"],
+	}
+}
+
+func hasRole(roles interface{}, id interface{}) bool {
+	granted, _ := roles.([]object)
+	for _, role := range granted {
+		if role["id"] == id {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutRole(roles interface{}, id interface{}) []object {
+	granted, _ := roles.([]object)
+	kept := []object{}
+	for _, role := range granted {
+		if role["id"] != id {
+			kept = append(kept, role)
+		}
+	}
+	return kept
+}
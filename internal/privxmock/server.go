@@ -0,0 +1,180 @@
+// Package privxmock provides an in-memory stand-in for the PrivX REST API,
+// used to run the provider acceptance tests without a PrivX appliance.
+package privxmock
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials accepted by the OAuth token endpoint of the mock.
+const (
+	APIClientID       = "mock-api-client-id"
+	APIClientSecret   = "mock-api-client-secret"
+	OAuthClientID     = "mock-oauth-client-id"
+	OAuthClientSecret = "mock-oauth-client-secret"
+)
+
+// Server is a running PrivX API mock.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	seq         int
+	tokens      map[string]bool
+	grants      int
+	lifetime    int
+	collections map[string]*collection
+	sessions    map[string]string
+	routes      []route
+}
+
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  func(w http.ResponseWriter, r *http.Request, params []string)
+}
+
+// New starts a PrivX API mock. It is stopped with Close.
+func New() *Server {
+	s := &Server{
+		tokens:      map[string]bool{},
+		lifetime:    3600,
+		collections: map[string]*collection{},
+		sessions:    map[string]string{},
+	}
+	s.registerHostStore()
+	s.registerRoleStore()
+	s.registerUserStore()
+	s.registerAuthorizer()
+	s.registerVault()
+
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+func (s *Server) handle(method, pattern string, handle func(w http.ResponseWriter, r *http.Request, params []string)) {
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: regexp.MustCompile("^" + pattern + "$"),
+		handle:  handle,
+	})
+}
+
+// ServeHTTP dispatches the request to the matching PrivX endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/auth/api/v1/oauth/token" {
+		s.token(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED")
+		return
+	}
+
+	for _, route := range s.routes {
+		params := route.pattern.FindStringSubmatch(r.URL.Path)
+		if params == nil || route.method != r.Method {
+			continue
+		}
+
+		s.mu.Lock()
+		route.handle(w, r, params[1:])
+		s.mu.Unlock()
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "NOT_FOUND")
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	digest := base64.StdEncoding.EncodeToString([]byte(OAuthClientID + ":" + OAuthClientSecret))
+	if r.Method != http.MethodPost ||
+		r.Header.Get("Authorization") != "Basic "+digest ||
+		r.PostFormValue("grant_type") != "password" ||
+		r.PostFormValue("username") != APIClientID ||
+		r.PostFormValue("password") != APIClientSecret {
+		writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS")
+		return
+	}
+
+	s.mu.Lock()
+	token := s.newID()
+	s.tokens[token] = true
+	s.grants++
+	lifetime := s.lifetime
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, object{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   lifetime,
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[token]
+}
+
+// RevokeTokens invalidates all the access tokens granted so far, as PrivX
+// does when they expire.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]bool{}
+}
+
+// SetTokenLifetime sets the expires_in, in seconds, of the access tokens
+// granted from now on. PrivX versions that do not report it are mimicked
+// with 0.
+func (s *Server) SetTokenLifetime(seconds int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lifetime = seconds
+}
+
+// TokenGrants returns the number of access tokens granted so far.
+func (s *Server) TokenGrants() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.grants
+}
+
+// newID returns a unique, UUID shaped, identifier.
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012x", s.seq)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, object{"error_code": code})
+}
+
+func readJSON(r *http.Request, body interface{}) error {
+	return json.NewDecoder(r.Body).Decode(body)
+}
@@ -0,0 +1,142 @@
+package privxmock
+
+import "net/http"
+
+func (s *Server) registerUserStore() {
+	trustedClients := s.collection("trusted_clients", "id", "secret", "registered", "oauth_client_id", "oauth_client_secret")
+	apiClients := s.collection("api_clients", "id", "secret", "oauth_client_id", "oauth_client_secret")
+	localUsers := s.collection("local_users", "id")
+
+	s.crud("/local-user-store/api/v1/trusted-clients", trustedClients, hooks{
+		create: func(obj object) string {
+			name, _ := obj["name"].(string)
+			if name == "" {
+				return "MISSING_NAME"
+			}
+			s.fillTrustedClient(obj)
+
+			// PrivX pairs every carrier with a web proxy sharing its group
+			if obj["type"] == "CARRIER" {
+				webProxy := object{
+					"id":                s.newID(),
+					"name":              name + "-webproxy",
+					"type":              "ICAP",
+					"enabled":           obj["enabled"],
+					"group_id":          obj["group_id"],
+					"permissions":       []interface{}{"privx-web-proxy"},
+					"web_proxy_address": obj["web_proxy_address"],
+					"created":           obj["created"],
+					"author":            obj["author"],
+				}
+				s.fillTrustedClient(webProxy)
+				trustedClients.put(webProxy)
+			}
+			return ""
+		},
+		update: func(obj object) string {
+			s.defaultTrustedClient(obj)
+			return ""
+		},
+		remove: func(obj object) {
+			if obj["type"] != "CARRIER" {
+				return
+			}
+			for _, tc := range trustedClients.list() {
+				if id, _ := tc["id"].(string); tc["type"] == "ICAP" && tc["group_id"] == obj["group_id"] {
+					trustedClients.remove(id)
+				}
+			}
+		},
+	})
+
+	s.crud("/local-user-store/api/v1/api-clients", apiClients, hooks{
+		create: func(obj object) string {
+			if name, _ := obj["name"].(string); name == "" {
+				return "MISSING_NAME"
+			}
+			obj["secret"] = s.newID()
+			obj["oauth_client_id"] = "privx-external"
+			obj["oauth_client_secret"] = s.newID()
+			obj["roles"] = s.roleRefs(obj["roles"])
+			return ""
+		},
+		update: func(obj object) string {
+			obj["roles"] = s.roleRefs(obj["roles"])
+			return ""
+		},
+	})
+
+	// PrivX never returns the passwords of local users
+	s.crud("/local-user-store/api/v1/users", localUsers, hooks{
+		create: func(obj object) string {
+			if username, _ := obj["username"].(string); username == "" {
+				return "MISSING_USERNAME"
+			}
+			if password, ok := obj["password"].(object); ok && password["password"] == "" {
+				return "MISSING_PASSWORD"
+			}
+			delete(obj, "password")
+			return ""
+		},
+		update: func(obj object) string {
+			if _, ok := obj["password"]; ok {
+				return "PASSWORD_NOT_UPDATABLE"
+			}
+			return ""
+		},
+		remove: func(obj object) {
+			id, _ := obj["id"].(string)
+			s.collection("role_grants", "id").remove(id)
+		},
+	})
+
+	s.handle(http.MethodPut, "/local-user-store/api/v1/users/([^/]+)/password", func(w http.ResponseWriter, r *http.Request, params []string) {
+		if _, ok := localUsers.get(params[0]); !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+
+		var body struct {
+			Password string `json:"password"`
+		}
+		if err := readJSON(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_JSON")
+			return
+		}
+		if body.Password == "" {
+			writeError(w, http.StatusBadRequest, "MISSING_PASSWORD")
+			return
+		}
+		writeJSON(w, http.StatusOK, object{})
+	})
+}
+
+// trustedClientPermissions are the permissions PrivX grants to each type of
+// trusted client.
+var trustedClientPermissions = map[interface{}]string{
+	"EXTENDER": "privx-extender",
+	"CARRIER":  "privx-carrier",
+	"ICAP":     "privx-web-proxy",
+}
+
+func (s *Server) fillTrustedClient(obj object) {
+	obj["secret"] = s.newID()
+	obj["oauth_client_id"] = "privx-" + s.newID()
+	obj["oauth_client_secret"] = s.newID()
+	obj["registered"] = false
+	s.defaultTrustedClient(obj)
+}
+
+func (s *Server) defaultTrustedClient(obj object) {
+	if obj["access_group_id"] == nil || obj["access_group_id"] == "" {
+		obj["access_group_id"] = DefaultAccessGroupID
+	}
+	if obj["group_id"] == nil || obj["group_id"] == "" {
+		obj["group_id"] = obj["id"]
+	}
+	if permissions, _ := obj["permissions"].([]interface{}); len(permissions) == 0 {
+		if permission, ok := trustedClientPermissions[obj["type"]]; ok {
+			obj["permissions"] = []interface{}{permission}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package privxmock
+
+func (s *Server) registerVault() {
+	secrets := s.collection("secrets", "name")
+
+	refs := func(obj object) string {
+		obj["read_roles"] = s.roleRefs(obj["read_roles"])
+		obj["write_roles"] = s.roleRefs(obj["write_roles"])
+		return ""
+	}
+	s.crud("/vault/api/v1/secrets", secrets, hooks{
+		create: refs,
+		update: refs,
+	})
+}
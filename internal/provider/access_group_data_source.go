@@ -3,12 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/authorizer"
-	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -23,6 +26,7 @@ func NewAccessGroupDataSource() datasource.DataSource {
 
 // AccessGroupDataSource defines the data source implementation.
 type AccessGroupDataSource struct {
+	endpoints
 	client *authorizer.Client
 }
 
@@ -37,6 +41,7 @@ type AccessGroupDataSourceModel struct {
 	Updated   types.String `tfsdk:"updated"`
 	UpdatedBy types.String `tfsdk:"updated_by"`
 	Default   types.Bool   `tfsdk:"default"`
+	Endpoint  types.String `tfsdk:"endpoint"`
 }
 
 func (d *AccessGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -46,15 +51,17 @@ func (d *AccessGroupDataSource) Metadata(ctx context.Context, req datasource.Met
 func (d *AccessGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Role data source",
+		MarkdownDescription: "Access group data source. Looks up the single access group matching all the given `id`, `name` and `default` attributes.",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "UUID",
+				Optional:            true,
 				Computed:            true,
 			},
 			"comment": schema.StringAttribute{
 				MarkdownDescription: "optional human readable description",
-				Optional:            true,
+				Computed:            true,
 			},
 			"ca_id": schema.StringAttribute{
 				MarkdownDescription: "UUID of access group's CA",
@@ -79,10 +86,12 @@ func (d *AccessGroupDataSource) Schema(ctx context.Context, req datasource.Schem
 			"name": schema.StringAttribute{
 				MarkdownDescription: "unique human reabable name for access group",
 				Optional:            true,
+				Computed:            true,
 			},
 			"default": schema.BoolAttribute{
-				MarkdownDescription: "Is default access group",
+				MarkdownDescription: "Is default access group. Set to `true` to look up the default access group",
 				Optional:            true,
+				Computed:            true,
 			},
 		},
 	}
@@ -94,61 +103,134 @@ func (d *AccessGroupDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *AccessGroupDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
-	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+	tflog.Debug(ctx, "Creating authorizer client", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
 	})
 
-	d.client = authorizer.New(*connector)
+	d.client = authorizer.New(connector)
+	return diags
 }
 
 func (d AccessGroupDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
 	return []datasource.ConfigValidator{
-		datasourcevalidator.Conflicting(
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
 			path.MatchRoot("name"),
 			path.MatchRoot("default"),
 		),
 	}
 }
 
+// listAccessGroups returns all the access groups, walking through the result
+// pages of the authorizer API.
+func listAccessGroups(authorizerClient *authorizer.Client) ([]authorizer.AccessGroup, error) {
+	const pageSize = 100
+
+	var accessGroups []authorizer.AccessGroup
+	for offset := 0; ; offset += pageSize {
+		page, err := authorizerClient.AccessGroups(offset, pageSize, "id", "ASC")
+		if err != nil {
+			return nil, err
+		}
+		accessGroups = append(accessGroups, page...)
+		if len(page) < pageSize {
+			return accessGroups, nil
+		}
+	}
+}
+
+// lookupAccessGroups returns the access groups matching all the lookup
+// attributes set in data.
+func (d *AccessGroupDataSource) lookupAccessGroups(data AccessGroupDataSourceModel) ([]authorizer.AccessGroup, error) {
+	var candidates []authorizer.AccessGroup
+	if !data.ID.IsNull() {
+		accessGroup, err := d.client.AccessGroup(data.ID.ValueString())
+		if client.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		candidates = []authorizer.AccessGroup{*accessGroup}
+	} else {
+		accessGroups, err := listAccessGroups(d.client)
+		if err != nil {
+			return nil, err
+		}
+		candidates = accessGroups
+	}
+
+	var accessGroups []authorizer.AccessGroup
+	for _, accessGroup := range candidates {
+		if !data.Name.IsNull() && accessGroup.Name != data.Name.ValueString() {
+			continue
+		}
+		if !data.Default.IsNull() && accessGroup.Default != data.Default.ValueBool() {
+			continue
+		}
+		accessGroups = append(accessGroups, accessGroup)
+	}
+	return accessGroups, nil
+}
+
+func accessGroupLookupCriteria(data AccessGroupDataSourceModel) string {
+	var criteria []string
+	if !data.ID.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("id=%q", data.ID.ValueString()))
+	}
+	if !data.Name.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("name=%q", data.Name.ValueString()))
+	}
+	if !data.Default.IsNull() {
+		criteria = append(criteria, fmt.Sprintf("default=%t", data.Default.ValueBool()))
+	}
+	return strings.Join(criteria, ", ")
+}
+
 func (d *AccessGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data AccessGroupDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	if data.Name.IsNull() && data.Default.IsNull() {
-		resp.Diagnostics.AddError("Configuration Error", "Name and Default cannot be null at the same time")
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	searchResult, err := d.client.AccessGroups(0, 1000, "id", "ASC")
+
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessGroups, err := d.lookupAccessGroups(data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access group, got error: %s", err))
 		return
 	}
-	var accessGroup authorizer.AccessGroup
-	for _, result := range searchResult {
-		if !data.Name.IsNull() {
-			if result.Name == data.Name.ValueString() {
-				accessGroup = result
-				break
-			}
-		}
-		if !data.Default.IsNull() {
-			if result.Default == data.Default.ValueBool() {
-				accessGroup = result
-				break
-			}
+
+	if len(accessGroups) == 0 {
+		resp.Diagnostics.AddError("Access Group Not Found", fmt.Sprintf("No access group matches %s", accessGroupLookupCriteria(data)))
+		return
+	}
+	if len(accessGroups) > 1 {
+		var ids []string
+		for _, accessGroup := range accessGroups {
+			ids = append(ids, accessGroup.ID)
 		}
+		resp.Diagnostics.AddError("Multiple Access Groups Found",
+			fmt.Sprintf("%d access groups match %s (%s), please narrow the lookup", len(accessGroups), accessGroupLookupCriteria(data), strings.Join(ids, ", ")))
+		return
 	}
+	accessGroup := accessGroups[0]
 
 	data.ID = types.StringValue(accessGroup.ID)
 	data.Name = types.StringValue(accessGroup.Name)
@@ -161,7 +243,7 @@ func (d *AccessGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 	data.Default = types.BoolValue(accessGroup.Default)
 
 	tflog.Debug(ctx, "Storing access group type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
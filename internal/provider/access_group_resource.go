@@ -3,13 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/authorizer"
-	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -25,14 +28,16 @@ func NewAccessGroupResource() resource.Resource {
 
 // AccessGroupResource defines the resource implementation.
 type AccessGroupResource struct {
+	endpoints
 	client *authorizer.Client
 }
 
 // AccessGroup contains PrivX access group information.
 type AccessGroupResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Name    types.String `tfsdk:"name"`
-	Comment types.String `tfsdk:"comment"`
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Comment  types.String `tfsdk:"comment"`
+	Endpoint types.String `tfsdk:"endpoint"`
 }
 
 func (r *AccessGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,6 +49,7 @@ func (r *AccessGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "AccessGroup resource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "AccessGroup ID",
 				Computed:            true,
@@ -61,6 +67,8 @@ func (r *AccessGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 			"comment": schema.StringAttribute{
 				MarkdownDescription: "AccessGroup comment",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
 			},
 		},
 	}
@@ -72,20 +80,21 @@ func (r *AccessGroupResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *AccessGroupResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating authorizer client", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = authorizer.New(*connector)
+	r.client = authorizer.New(connector)
+	return diags
 }
 
 func (r *AccessGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -98,8 +107,13 @@ func (r *AccessGroupResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Loaded accessGroup type data", map[string]interface{}{
-		"data": fmt.Sprintf("%+v", data),
+		"data": utils.Redacted(data),
 	})
 
 	accessGroup := authorizer.AccessGroup{
@@ -107,7 +121,7 @@ func (r *AccessGroupResource) Create(ctx context.Context, req resource.CreateReq
 		Comment: data.Comment.ValueString(),
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("authorizer.AccessGroup model used: %+v", accessGroup))
+	tflog.Debug(ctx, "authorizer.AccessGroup model used: "+utils.Redacted(accessGroup))
 
 	accessGroupID, err := r.client.CreateAccessGroup(&accessGroup)
 
@@ -142,7 +156,17 @@ func (r *AccessGroupResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	accessGroup, err := r.client.AccessGroup(data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "access group not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access group, got error: %s", err))
 		return
@@ -152,7 +176,7 @@ func (r *AccessGroupResource) Read(ctx context.Context, req resource.ReadRequest
 	data.Comment = types.StringValue(accessGroup.Comment)
 
 	tflog.Debug(ctx, "Storing access group type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -168,12 +192,17 @@ func (r *AccessGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	accessGroup := authorizer.AccessGroup{
 		Name:    data.Name.ValueString(),
 		Comment: data.Comment.ValueString(),
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("authorizer.AccessGroup model used: %+v", accessGroup))
+	tflog.Debug(ctx, "authorizer.AccessGroup model used: "+utils.Redacted(accessGroup))
 
 	err := r.client.UpdateAccessGroup(
 		data.ID.ValueString(),
@@ -197,6 +226,11 @@ func (r *AccessGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteAccessGroup(data.ID.ValueString())
 
 	if err != nil {
@@ -206,5 +240,5 @@ func (r *AccessGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *AccessGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.importState(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccessGroupResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_access_group", "id", func(connector restapi.Connector, id string) error {
+			_, err := authorizer.New(connector).AccessGroup(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAccessGroupResourceConfig(name, "first comment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_access_group.test", "name", name),
+					resource.TestCheckResourceAttr("privx_access_group.test", "comment", "first comment"),
+					resource.TestCheckResourceAttrSet("privx_access_group.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_access_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAccessGroupResourceConfig(name+"-renamed", "second comment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_access_group.test", "name", name+"-renamed"),
+					resource.TestCheckResourceAttr("privx_access_group.test", "comment", "second comment"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAccessGroupDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessGroupResourceConfig(name, "comment") + `
+data "privx_access_group" "test" {
+  name = privx_access_group.test.name
+}
+
+data "privx_access_group" "default" {
+  default = true
+}
+
+data "privx_access_group" "by_id" {
+  id = privx_access_group.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.privx_access_group.test", "id", "privx_access_group.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_access_group.by_id", "name", name),
+					resource.TestCheckResourceAttr("data.privx_access_group.by_id", "comment", "comment"),
+					resource.TestCheckResourceAttr("data.privx_access_group.test", "comment", "comment"),
+					resource.TestCheckResourceAttr("data.privx_access_group.test", "default", "false"),
+					resource.TestCheckResourceAttr("data.privx_access_group.default", "name", "Default"),
+					resource.TestCheckResourceAttr("data.privx_access_group.default", "default", "true"),
+				),
+			},
+			{
+				Config: testAccAccessGroupResourceConfig(name, "comment") + `
+data "privx_access_group" "test" {
+  name = "${privx_access_group.test.name}-missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`No access group matches name="` + name + `-missing"`),
+			},
+			{
+				Config: testAccAccessGroupResourceConfig(name, "comment") + fmt.Sprintf(`
+resource "privx_access_group" "other" {
+  name = %q
+}
+
+data "privx_access_group" "test" {
+  default    = false
+  depends_on = [privx_access_group.test, privx_access_group.other]
+}
+`, name+"-other"),
+				ExpectError: regexp.MustCompile(`access groups match default=false`),
+			},
+		},
+	})
+}
+
+func TestAccAccessGroupsDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	// More access groups than fit in a page of the authorizer API
+	prefixed := 1
+	if testAccMock {
+		prefixed += 100
+		authorizerClient := authorizer.New(testAccConnector(t))
+		for i := 0; i < 100; i++ {
+			id, err := authorizerClient.CreateAccessGroup(&authorizer.AccessGroup{Name: fmt.Sprintf("%s-page-%d", name, i)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = authorizerClient.DeleteAccessGroup(id) })
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessGroupResourceConfig(name, "comment") + `
+data "privx_access_groups" "test" {
+  name_regex = "^${privx_access_group.test.name}$"
+}
+
+data "privx_access_groups" "prefixed" {
+  name_regex = "^${privx_access_group.test.name}"
+}
+
+data "privx_access_groups" "all" {
+  depends_on = [privx_access_group.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_access_groups.test", "access_groups.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_access_groups.test", "access_groups.0.id", "privx_access_group.test", "id"),
+					resource.TestCheckResourceAttrPair("data.privx_access_groups.test", "ids.0", "privx_access_group.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_access_groups.test", "access_groups.0.comment", "comment"),
+					resource.TestCheckResourceAttr("data.privx_access_groups.prefixed", "ids.#", strconv.Itoa(prefixed)),
+					resource.TestCheckTypeSetElemNestedAttrs("data.privx_access_groups.all", "access_groups.*", map[string]string{
+						"name":    "Default",
+						"default": "true",
+					}),
+					resource.TestCheckTypeSetElemAttrPair("data.privx_access_groups.all", "ids.*", "privx_access_group.test", "id"),
+				),
+			},
+			{
+				Config: `
+data "privx_access_groups" "test" {
+  name_regex = "["
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+		},
+	})
+}
+
+func testAccAccessGroupResourceConfig(name, comment string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name    = %[1]q
+  comment = %[2]q
+}
+`, name, comment)
+}
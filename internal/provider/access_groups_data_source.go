@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/SSHcom/privx-sdk-go/api/authorizer"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AccessGroupsDataSource{}
+
+func NewAccessGroupsDataSource() datasource.DataSource {
+	return &AccessGroupsDataSource{}
+}
+
+// AccessGroupsDataSource defines the data source implementation.
+type AccessGroupsDataSource struct {
+	endpoints
+	client *authorizer.Client
+}
+
+// AccessGroupsDataSourceModel describes the data source data model.
+type (
+	AccessGroupSummaryModel struct {
+		ID        types.String `tfsdk:"id"`
+		Name      types.String `tfsdk:"name"`
+		Comment   types.String `tfsdk:"comment"`
+		CAID      types.String `tfsdk:"ca_id"`
+		Author    types.String `tfsdk:"author"`
+		Created   types.String `tfsdk:"created"`
+		Updated   types.String `tfsdk:"updated"`
+		UpdatedBy types.String `tfsdk:"updated_by"`
+		Default   types.Bool   `tfsdk:"default"`
+	}
+
+	AccessGroupsDataSourceModel struct {
+		NameRegex    types.String              `tfsdk:"name_regex"`
+		AccessGroups []AccessGroupSummaryModel `tfsdk:"access_groups"`
+		IDs          types.List                `tfsdk:"ids"`
+		Endpoint     types.String              `tfsdk:"endpoint"`
+	}
+)
+
+func (d *AccessGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_groups"
+}
+
+func (d *AccessGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Access groups data source. Lists the access groups, every access group when no filter is set.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the names of the access groups match, in the RE2 syntax",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the matching access groups",
+				Computed:            true,
+			},
+			"access_groups": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching access groups",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "UUID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "unique human reabable name for access group",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "optional human readable description",
+							Computed:            true,
+						},
+						"ca_id": schema.StringAttribute{
+							MarkdownDescription: "UUID of access group's CA",
+							Computed:            true,
+						},
+						"author": schema.StringAttribute{
+							MarkdownDescription: "ID of the user who originally authored the object",
+							Computed:            true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: "When the object was created",
+							Computed:            true,
+						},
+						"updated": schema.StringAttribute{
+							MarkdownDescription: "When the object was updated",
+							Computed:            true,
+						},
+						"updated_by": schema.StringAttribute{
+							MarkdownDescription: "ID of the user who updated the object",
+							Computed:            true,
+						},
+						"default": schema.BoolAttribute{
+							MarkdownDescription: "Is default access group",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AccessGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *AccessGroupsDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating authorizer client", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	d.client = authorizer.New(connector)
+	return diags
+}
+
+func (d *AccessGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccessGroupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression",
+				fmt.Sprintf("Unable to parse name_regex, got error: %s", err))
+			return
+		}
+	}
+
+	accessGroups, err := listAccessGroups(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list access groups, got error: %s", err))
+		return
+	}
+
+	data.AccessGroups = []AccessGroupSummaryModel{}
+	ids := []string{}
+	for _, accessGroup := range accessGroups {
+		if nameRegex != nil && !nameRegex.MatchString(accessGroup.Name) {
+			continue
+		}
+
+		ids = append(ids, accessGroup.ID)
+		data.AccessGroups = append(data.AccessGroups, AccessGroupSummaryModel{
+			ID:        types.StringValue(accessGroup.ID),
+			Name:      types.StringValue(accessGroup.Name),
+			Comment:   types.StringValue(accessGroup.Comment),
+			CAID:      types.StringValue(accessGroup.CAID),
+			Author:    types.StringValue(accessGroup.Author),
+			Created:   types.StringValue(accessGroup.Created),
+			Updated:   types.StringValue(accessGroup.Updated),
+			UpdatedBy: types.StringValue(accessGroup.UpdatedBy),
+			Default:   types.BoolValue(accessGroup.Default),
+		})
+	}
+
+	var diags diag.Diagnostics
+	data.IDs, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Storing access groups into the state", map[string]interface{}{
+		"accessGroupCount": len(data.AccessGroups),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 
 	"github.com/SSHcom/privx-sdk-go/api/userstore"
-	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +21,7 @@ func NewAPIClientDataSource() datasource.DataSource {
 
 // APIClientDataSource defines the data source implementation.
 type APIClientDataSource struct {
+	endpoints
 	client *userstore.UserStore
 }
 
@@ -34,6 +35,7 @@ type APIClientDataSourceModel struct {
 	Roles             []RoleRefModel `tfsdk:"roles"`
 	OAuthClientID     types.String   `tfsdk:"oauth_client_id"`
 	OAuthClientSecret types.String   `tfsdk:"oauth_client_secret"`
+	Endpoint          types.String   `tfsdk:"endpoint"`
 }
 
 func (d *APIClientDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -45,6 +47,7 @@ func (d *APIClientDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example data source",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "ID of the API client",
@@ -100,20 +103,21 @@ func (d *APIClientDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *APIClientDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	d.client = userstore.New(*connector)
+	d.client = userstore.New(connector)
+	return diags
 }
 
 func (d *APIClientDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -126,6 +130,11 @@ func (d *APIClientDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	apiClient, err := d.client.APIClient(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API client, got error: %s", err))
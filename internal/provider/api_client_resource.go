@@ -3,10 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/client"
 
 	"github.com/SSHcom/privx-sdk-go/api/rolestore"
 	"github.com/SSHcom/privx-sdk-go/api/userstore"
-	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -26,6 +27,7 @@ func NewAPIClientResource() resource.Resource {
 
 // APIClientResource defines the resource implementation.
 type APIClientResource struct {
+	endpoints
 	client *userstore.UserStore
 }
 
@@ -37,6 +39,7 @@ type APIClientModel struct {
 	OauthClientId     types.String   `tfsdk:"oauth_client_id"`
 	OauthClientSecret types.String   `tfsdk:"oauth_client_secret"`
 	Roles             []RoleRefModel `tfsdk:"roles"`
+	Endpoint          types.String   `tfsdk:"endpoint"`
 }
 
 func (r *APIClientResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,6 +52,7 @@ func (r *APIClientResource) Schema(ctx context.Context, req resource.SchemaReque
 		MarkdownDescription: "API client resource",
 
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "ID of the API client",
@@ -106,20 +110,21 @@ func (r *APIClientResource) Configure(ctx context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *APIClientResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = userstore.New(*connector)
+	r.client = userstore.New(connector)
+	return diags
 }
 
 func (r *APIClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -132,6 +137,11 @@ func (r *APIClientResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var rolesPayload []string
 	for _, roleRef := range data.Roles {
 		rolesPayload = append(rolesPayload, roleRef.ID.ValueString())
@@ -186,7 +196,17 @@ func (r *APIClientResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	apiClient, err := r.client.APIClient(data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "API client not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API client, got error: %s", err))
 		return
@@ -218,6 +238,11 @@ func (r *APIClientResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var rolesPayload []rolestore.RoleRef
 	for _, roleRef := range data.Roles {
 		rolesPayload = append(rolesPayload,
@@ -251,6 +276,11 @@ func (r *APIClientResource) Delete(ctx context.Context, req resource.DeleteReque
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -261,5 +291,5 @@ func (r *APIClientResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *APIClientResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.importState(ctx, path.Root("id"), req, resp)
 }
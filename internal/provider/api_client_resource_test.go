@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAPIClientResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_api_client", "id", func(connector restapi.Connector, id string) error {
+			_, err := userstore.New(connector).APIClient(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccAPIClientResourceConfig(name, "privx_role.first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_api_client.test", "name", name),
+					resource.TestCheckResourceAttr("privx_api_client.test", "roles.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_api_client.test", "roles.*", map[string]string{"name": name + "-first"}),
+					resource.TestCheckResourceAttrSet("privx_api_client.test", "secret"),
+					resource.TestCheckResourceAttrSet("privx_api_client.test", "oauth_client_id"),
+					resource.TestCheckResourceAttrSet("privx_api_client.test", "oauth_client_secret"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_api_client.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccAPIClientResourceConfig(name, "privx_role.second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_api_client.test", "roles.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_api_client.test", "roles.*", map[string]string{"name": name + "-second"}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAPIClientDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIClientResourceConfig(name, "privx_role.first") + `
+data "privx_api_client" "test" {
+  id = privx_api_client.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.privx_api_client.test", "name", "privx_api_client.test", "name"),
+					resource.TestCheckResourceAttrPair("data.privx_api_client.test", "secret", "privx_api_client.test", "secret"),
+					resource.TestCheckResourceAttr("data.privx_api_client.test", "roles.#", "1"),
+					resource.TestCheckResourceAttrSet("data.privx_api_client.test", "created"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAPIClientResourceConfig(name, role string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_role" "first" {
+  name            = "%[1]s-first"
+  access_group_id = privx_access_group.test.id
+}
+
+resource "privx_role" "second" {
+  name            = "%[1]s-second"
+  access_group_id = privx_access_group.test.id
+}
+
+resource "privx_api_client" "test" {
+  name = %[1]q
+  roles = [
+    {
+      id   = %[2]s.id
+      name = %[2]s.name
+    },
+  ]
+}
+`, name, role)
+}
@@ -3,11 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/authorizer"
 	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +23,7 @@ func NewCarrierConfigDataSource() datasource.DataSource {
 
 // CarrierConfigDataSource defines the DataSource implementation.
 type CarrierConfigDataSource struct {
+	endpoints
 	client    *authorizer.Client
 	connector *restapi.Connector
 }
@@ -29,6 +32,7 @@ type CarrierConfigDataSource struct {
 type CarrierConfigDataSourceModel struct {
 	TrustedClientID types.String `tfsdk:"trusted_client_id"`
 	CarrierConfig   types.String `tfsdk:"carrier_config"`
+	Endpoint        types.String `tfsdk:"endpoint"`
 }
 
 func (r *CarrierConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +44,7 @@ func (r *CarrierConfigDataSource) Schema(ctx context.Context, req datasource.Sch
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "CarrierConfig DataSource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"trusted_client_id": schema.StringAttribute{
 				MarkdownDescription: "CarrierConfig ID",
 				Required:            true,
@@ -58,22 +63,22 @@ func (r *CarrierConfigDataSource) Configure(ctx context.Context, req datasource.
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (r *CarrierConfigDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.connector = connector
-
-	r.client = authorizer.New(*connector)
+	r.connector = &connector
+	r.client = authorizer.New(connector)
+	return diags
 }
 
 func (r *CarrierConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -86,6 +91,11 @@ func (r *CarrierConfigDataSource) Read(ctx context.Context, req datasource.ReadR
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	DownloadHandle, err := r.client.CarrierConfigDownloadHandle(data.TrustedClientID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get carrier download sessionid, got error: %s", err))
@@ -100,7 +110,7 @@ func (r *CarrierConfigDataSource) Read(ctx context.Context, req datasource.ReadR
 	data.CarrierConfig = types.StringValue(carrier_config)
 
 	tflog.Debug(ctx, "Storing CarrierConfig type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
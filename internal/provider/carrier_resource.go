@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/userstore"
-	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,6 +30,7 @@ func NewCarrierResource() resource.Resource {
 
 // CarrierResource defines the resource implementation.
 type CarrierResource struct {
+	endpoints
 	client *userstore.UserStore
 }
 
@@ -47,6 +50,7 @@ type CarrierResourceModel struct {
 	Registered                    types.Bool   `tfsdk:"registered"`
 	AccessGroupId                 types.String `tfsdk:"access_group_id"`
 	GroupID                       types.String `tfsdk:"group_id"`
+	Endpoint                      types.String `tfsdk:"endpoint"`
 }
 
 func (r *CarrierResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,6 +62,7 @@ func (r *CarrierResource) Schema(ctx context.Context, req resource.SchemaRequest
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Carrier resource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Carrier ID",
 				Computed:            true,
@@ -150,20 +155,21 @@ func (r *CarrierResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *CarrierResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = userstore.New(*connector)
+	r.client = userstore.New(connector)
+	return diags
 }
 
 func (r *CarrierResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -176,8 +182,13 @@ func (r *CarrierResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Loaded Carrier type data", map[string]interface{}{
-		"data": fmt.Sprintf("%+v", data),
+		"data": utils.Redacted(data),
 	})
 
 	permissionPayload := []string{"privx-carrier"}
@@ -220,7 +231,7 @@ func (r *CarrierResource) Create(ctx context.Context, req resource.CreateRequest
 		RoutingPrefix:                 data.RoutingPrefix.ValueString(),
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("userstore.TrustedClient model used: %+v", carrier))
+	tflog.Debug(ctx, "userstore.TrustedClient model used: "+utils.Redacted(carrier))
 
 	carrierID, err := r.client.CreateTrustedClient(carrier)
 
@@ -279,16 +290,36 @@ func (r *CarrierResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	carrier, err := r.client.TrustedClient(data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "carrier not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read carrier, got error: %s", err))
 		return
 	}
 
+	data.Type = types.StringValue(string(carrier.Type))
 	data.Name = types.StringValue(carrier.Name)
 	data.Registered = types.BoolValue(carrier.Registered)
 	data.Enabled = types.BoolValue(carrier.Enabled)
 	data.RoutingPrefix = types.StringValue(carrier.RoutingPrefix)
+	data.WebProxyAddress = types.StringValue(carrier.WebProxyAddress)
+	data.AccessGroupId = types.StringValue(carrier.AccessGroupId)
+	data.GroupID = types.StringValue(carrier.GroupId)
+
+	routePatterns, diags := types.ListValueFrom(ctx, data.WebProxyExtenderRoutePatterns.ElementType(ctx), carrier.WebProxyExtenderRoutePatterns)
+	if diags.HasError() {
+		return
+	}
+	data.WebProxyExtenderRoutePatterns = routePatterns
 
 	subnets, diags := types.ListValueFrom(ctx, data.Subnets.ElementType(ctx), carrier.Subnets)
 	if diags.HasError() {
@@ -309,7 +340,7 @@ func (r *CarrierResource) Read(ctx context.Context, req resource.ReadRequest, re
 	data.ExtenderAddress = extenderAddress
 
 	tflog.Debug(ctx, "Storing carrier type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -325,6 +356,11 @@ func (r *CarrierResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var extenderAddressPayload []string
 	if len(data.ExtenderAddress.Elements()) > 0 {
 		resp.Diagnostics.Append(data.ExtenderAddress.ElementsAs(ctx, &extenderAddressPayload, false)...)
@@ -365,7 +401,7 @@ func (r *CarrierResource) Update(ctx context.Context, req resource.UpdateRequest
 		RoutingPrefix:                 data.RoutingPrefix.ValueString(),
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("userstore.TrustedClient model used: %+v", carrier))
+	tflog.Debug(ctx, "userstore.TrustedClient model used: "+utils.Redacted(carrier))
 
 	err := r.client.UpdateTrustedClient(
 		data.ID.ValueString(),
@@ -389,6 +425,11 @@ func (r *CarrierResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteTrustedClient(data.ID.ValueString())
 
 	if err != nil {
@@ -398,5 +439,5 @@ func (r *CarrierResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *CarrierResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.importState(ctx, path.Root("id"), req, resp)
 }
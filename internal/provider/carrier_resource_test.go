@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCarrierResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_carrier", "id", func(connector restapi.Connector, id string) error {
+			_, err := userstore.New(connector).TrustedClient(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCarrierResourceConfig(name, true, `"10.0.0.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_carrier.test", "name", name),
+					resource.TestCheckResourceAttr("privx_carrier.test", "type", "CARRIER"),
+					resource.TestCheckResourceAttr("privx_carrier.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("privx_carrier.test", "registered", "false"),
+					resource.TestCheckResourceAttr("privx_carrier.test", "permissions.0", "privx-carrier"),
+					resource.TestCheckResourceAttr("privx_carrier.test", "subnets.#", "1"),
+					resource.TestCheckResourceAttrSet("privx_carrier.test", "group_id"),
+					resource.TestCheckResourceAttrSet("privx_carrier.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "privx_carrier.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"web_proxy_port"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccCarrierResourceConfig(name, false, `"10.0.0.0/24", "10.0.1.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_carrier.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("privx_carrier.test", "subnets.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccCarrierDataSources(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCarrierResourceConfig(name, true, `"10.0.0.0/24"`) + `
+data "privx_carrier_config" "test" {
+  trusted_client_id = privx_carrier.test.id
+}
+
+data "privx_webproxy" "test" {
+  group_id = privx_carrier.test.group_id
+}
+
+data "privx_webproxy_config" "test" {
+  trusted_client_id = data.privx_webproxy.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.privx_carrier_config.test", "carrier_config"),
+					resource.TestCheckResourceAttrSet("data.privx_webproxy.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_webproxy.test", "permissions.0", "privx-web-proxy"),
+					resource.TestCheckResourceAttr("data.privx_webproxy.test", "web_proxy_address", "10.0.0.2"),
+					resource.TestCheckResourceAttrSet("data.privx_webproxy_config.test", "webproxy_config"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCarrierResourceConfig(name string, enabled bool, subnets string) string {
+	return fmt.Sprintf(`
+resource "privx_carrier" "test" {
+  name                              = %[1]q
+  enabled                           = %[2]t
+  routing_prefix                    = "carrier"
+  web_proxy_address                 = "10.0.0.2"
+  web_proxy_extender_route_patterns = ["*.example.com"]
+  extender_address                  = ["10.0.0.1"]
+  subnets                           = [%[3]s]
+}
+`, name, enabled, subnets)
+}
@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CommandWhitelistEvaluationDataSource{}
+
+func NewCommandWhitelistEvaluationDataSource() datasource.DataSource {
+	return &CommandWhitelistEvaluationDataSource{}
+}
+
+// CommandWhitelistEvaluationDataSource defines the data source implementation.
+type CommandWhitelistEvaluationDataSource struct {
+	endpoints
+	connector restapi.Connector
+}
+
+// whitelistEvaluation is what the whitelist evaluation endpoint takes.
+type whitelistEvaluation struct {
+	Whitelist     commandWhitelist `json:"whitelist"`
+	RShellVariant string           `json:"rshell_variant"`
+	Commands      []string         `json:"commands"`
+}
+
+// whitelistEvaluationResult tells which commands the whitelist allows, and
+// whether its patterns are valid.
+type whitelistEvaluationResult struct {
+	WhitelistPatternResults []struct {
+		WhitelistPattern string         `json:"whitelist_pattern"`
+		Status           []statusResult `json:"status"`
+	} `json:"whitelist_pattern_results"`
+	CommandResults []struct {
+		Command string         `json:"command"`
+		Allowed bool           `json:"allowed"`
+		Status  []statusResult `json:"status"`
+	} `json:"command_results"`
+}
+
+type statusResult struct {
+	K string `json:"k"`
+	V string `json:"v"`
+}
+
+// CommandWhitelistEvaluationDataSourceModel describes the data source data model.
+type (
+	WhitelistPatternResultModel struct {
+		WhitelistPattern types.String  `tfsdk:"whitelist_pattern"`
+		Status           []StatusModel `tfsdk:"status"`
+	}
+
+	CommandResultModel struct {
+		Command types.String  `tfsdk:"command"`
+		Allowed types.Bool    `tfsdk:"allowed"`
+		Status  []StatusModel `tfsdk:"status"`
+	}
+
+	CommandWhitelistEvaluationDataSourceModel struct {
+		WhitelistID             types.String                  `tfsdk:"whitelist_id"`
+		Type                    types.String                  `tfsdk:"type"`
+		WhitelistPatterns       types.List                    `tfsdk:"whitelist_patterns"`
+		RShellVariant           types.String                  `tfsdk:"rshell_variant"`
+		Commands                types.List                    `tfsdk:"commands"`
+		WhitelistPatternResults []WhitelistPatternResultModel `tfsdk:"whitelist_pattern_results"`
+		CommandResults          []CommandResultModel          `tfsdk:"command_results"`
+		AllowedCommands         types.List                    `tfsdk:"allowed_commands"`
+		DeniedCommands          types.List                    `tfsdk:"denied_commands"`
+		Endpoint                types.String                  `tfsdk:"endpoint"`
+	}
+)
+
+func (d *CommandWhitelistEvaluationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_command_whitelist_evaluation"
+}
+
+func statusAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Status messages of the evaluation",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"k": schema.StringAttribute{
+					MarkdownDescription: "Status key",
+					Computed:            true,
+				},
+				"v": schema.StringAttribute{
+					MarkdownDescription: "Status value",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func (d *CommandWhitelistEvaluationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Command whitelist evaluation data source. Evaluates commands against an existing whitelist, given by `whitelist_id`, " +
+			"or against `whitelist_patterns`, to validate whitelists before restricting principals with them.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
+			"whitelist_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the whitelist to evaluate",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Syntax of `whitelist_patterns`, `GLOB` or `REGEX`. Defaults to `GLOB`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("GLOB", "REGEX"),
+				},
+			},
+			"whitelist_patterns": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Patterns to evaluate, in place of those of a whitelist",
+				Optional:            true,
+			},
+			"rshell_variant": schema.StringAttribute{
+				MarkdownDescription: "Restricted shell variant the commands are run with, `bash` or `posix`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("bash", "posix"),
+				},
+			},
+			"commands": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Commands to evaluate",
+				Required:            true,
+			},
+			"whitelist_pattern_results": schema.ListNestedAttribute{
+				MarkdownDescription: "Evaluation of the patterns of the whitelist",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"whitelist_pattern": schema.StringAttribute{
+							MarkdownDescription: "Whitelist pattern",
+							Computed:            true,
+						},
+						"status": statusAttribute(),
+					},
+				},
+			},
+			"command_results": schema.ListNestedAttribute{
+				MarkdownDescription: "Evaluation of the commands, in the order of `commands`",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"command": schema.StringAttribute{
+							MarkdownDescription: "Command",
+							Computed:            true,
+						},
+						"allowed": schema.BoolAttribute{
+							MarkdownDescription: "Does the whitelist allow the command",
+							Computed:            true,
+						},
+						"status": statusAttribute(),
+					},
+				},
+			},
+			"allowed_commands": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Commands the whitelist allows",
+				Computed:            true,
+			},
+			"denied_commands": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Commands the whitelist denies",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CommandWhitelistEvaluationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *CommandWhitelistEvaluationDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating hoststore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	d.connector = connector
+	return diags
+}
+
+func (d CommandWhitelistEvaluationDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("whitelist_id"),
+			path.MatchRoot("whitelist_patterns"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("whitelist_id"),
+			path.MatchRoot("type"),
+		),
+	}
+}
+
+func evaluateCommandWhitelist(connector restapi.Connector, evaluation *whitelistEvaluation) (*whitelistEvaluationResult, error) {
+	result := &whitelistEvaluationResult{}
+
+	_, err := connector.
+		URL("/host-store/api/v1/whitelists/evaluate").
+		Post(evaluation, result)
+
+	return result, err
+}
+
+func newStatusModels(status []statusResult) []StatusModel {
+	var models []StatusModel
+	for _, s := range status {
+		models = append(models, StatusModel{
+			K: types.StringValue(s.K),
+			V: types.StringValue(s.V),
+		})
+	}
+	return models
+}
+
+func (d *CommandWhitelistEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CommandWhitelistEvaluationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	evaluation := whitelistEvaluation{
+		RShellVariant: data.RShellVariant.ValueString(),
+		Commands:      []string{},
+	}
+	resp.Diagnostics.Append(data.Commands.ElementsAs(ctx, &evaluation.Commands, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.WhitelistID.IsNull() {
+		evaluation.Whitelist = commandWhitelist{
+			Type:              "GLOB",
+			WhitelistPatterns: []string{},
+		}
+		if !data.Type.IsNull() {
+			evaluation.Whitelist.Type = data.Type.ValueString()
+		}
+		resp.Diagnostics.Append(data.WhitelistPatterns.ElementsAs(ctx, &evaluation.Whitelist.WhitelistPatterns, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		whitelist, err := getCommandWhitelist(d.connector, data.WhitelistID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read command whitelist, got error: %s", err))
+			return
+		}
+		evaluation.Whitelist = *whitelist
+	}
+
+	result, err := evaluateCommandWhitelist(d.connector, &evaluation)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to evaluate command whitelist, got error: %s", err))
+		return
+	}
+
+	data.WhitelistPatternResults = []WhitelistPatternResultModel{}
+	for _, r := range result.WhitelistPatternResults {
+		data.WhitelistPatternResults = append(data.WhitelistPatternResults, WhitelistPatternResultModel{
+			WhitelistPattern: types.StringValue(r.WhitelistPattern),
+			Status:           newStatusModels(r.Status),
+		})
+	}
+
+	data.CommandResults = []CommandResultModel{}
+	allowed, denied := []string{}, []string{}
+	for _, r := range result.CommandResults {
+		data.CommandResults = append(data.CommandResults, CommandResultModel{
+			Command: types.StringValue(r.Command),
+			Allowed: types.BoolValue(r.Allowed),
+			Status:  newStatusModels(r.Status),
+		})
+		if r.Allowed {
+			allowed = append(allowed, r.Command)
+		} else {
+			denied = append(denied, r.Command)
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.AllowedCommands, diags = types.ListValueFrom(ctx, types.StringType, allowed)
+	resp.Diagnostics.Append(diags...)
+	data.DeniedCommands, diags = types.ListValueFrom(ctx, types.StringType, denied)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Storing command whitelist evaluation into the state", map[string]interface{}{
+		"commandCount": len(data.CommandResults),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
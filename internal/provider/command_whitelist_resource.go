@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"terraform-provider-privx/internal/client"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CommandWhitelistResource{}
+var _ resource.ResourceWithImportState = &CommandWhitelistResource{}
+
+func NewCommandWhitelistResource() resource.Resource {
+	return &CommandWhitelistResource{}
+}
+
+// CommandWhitelistResource defines the resource implementation.
+type CommandWhitelistResource struct {
+	endpoints
+	connector restapi.Connector
+}
+
+// commandWhitelist is a whitelist of the commands principals with command
+// restrictions may run. privx-sdk-go does not model whitelists.
+type commandWhitelist struct {
+	ID                string   `json:"id,omitempty"`
+	Name              string   `json:"name"`
+	Comment           string   `json:"comment"`
+	Type              string   `json:"type"`
+	WhitelistPatterns []string `json:"whitelist_patterns"`
+}
+
+// CommandWhitelistResourceModel describes the resource data model.
+type CommandWhitelistResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Comment           types.String `tfsdk:"comment"`
+	Type              types.String `tfsdk:"type"`
+	WhitelistPatterns types.List   `tfsdk:"whitelist_patterns"`
+	Endpoint          types.String `tfsdk:"endpoint"`
+}
+
+func (r *CommandWhitelistResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_command_whitelist"
+}
+
+func (r *CommandWhitelistResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Command whitelist resource. Whitelists list the commands the principals of hosts with `command_restrictions` may run.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Whitelist ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Whitelist name",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "A comment describing the whitelist",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Syntax of the patterns, `GLOB` or `REGEX`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("GLOB"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("GLOB", "REGEX"),
+				},
+			},
+			"whitelist_patterns": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Patterns of the allowed commands, matched against the whole command line",
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			},
+		},
+	}
+}
+
+func (r *CommandWhitelistResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *CommandWhitelistResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating hoststore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	r.connector = connector
+	return diags
+}
+
+// payload returns the whitelist described by the model.
+func (data *CommandWhitelistResourceModel) payload(ctx context.Context) (*commandWhitelist, diag.Diagnostics) {
+	patterns := []string{}
+	diags := data.WhitelistPatterns.ElementsAs(ctx, &patterns, false)
+
+	return &commandWhitelist{
+		Name:              data.Name.ValueString(),
+		Comment:           data.Comment.ValueString(),
+		Type:              data.Type.ValueString(),
+		WhitelistPatterns: patterns,
+	}, diags
+}
+
+// The whitelist calls below go through the connector directly, as
+// privx-sdk-go has no whitelist API.
+
+func createCommandWhitelist(connector restapi.Connector, whitelist *commandWhitelist) (string, error) {
+	var object struct {
+		ID string `json:"id"`
+	}
+
+	_, err := connector.
+		URL("/host-store/api/v1/whitelists").
+		Post(whitelist, &object)
+
+	return object.ID, err
+}
+
+func getCommandWhitelist(connector restapi.Connector, whitelistID string) (*commandWhitelist, error) {
+	whitelist := &commandWhitelist{}
+
+	_, err := connector.
+		URL("/host-store/api/v1/whitelists/%s", url.PathEscape(whitelistID)).
+		Get(whitelist)
+
+	return whitelist, err
+}
+
+func updateCommandWhitelist(connector restapi.Connector, whitelistID string, whitelist *commandWhitelist) error {
+	_, err := connector.
+		URL("/host-store/api/v1/whitelists/%s", url.PathEscape(whitelistID)).
+		Put(whitelist)
+
+	return err
+}
+
+func deleteCommandWhitelist(connector restapi.Connector, whitelistID string) error {
+	_, err := connector.
+		URL("/host-store/api/v1/whitelists/%s", url.PathEscape(whitelistID)).
+		Delete()
+
+	return err
+}
+
+func (r *CommandWhitelistResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *CommandWhitelistResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diags := data.payload(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	whitelistID, err := createCommandWhitelist(r.connector, payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create command whitelist, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(whitelistID)
+
+	tflog.Debug(ctx, "created command whitelist resource", map[string]interface{}{
+		"id": whitelistID,
+	})
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommandWhitelistResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *CommandWhitelistResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	whitelist, err := getCommandWhitelist(r.connector, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "command whitelist not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read command whitelist, got error: %s", err))
+		return
+	}
+
+	data.Name = types.StringValue(whitelist.Name)
+	data.Comment = types.StringValue(whitelist.Comment)
+	data.Type = types.StringValue(whitelist.Type)
+
+	if whitelist.WhitelistPatterns == nil {
+		whitelist.WhitelistPatterns = []string{}
+	}
+	patterns, diags := types.ListValueFrom(ctx, types.StringType, whitelist.WhitelistPatterns)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.WhitelistPatterns = patterns
+
+	tflog.Debug(ctx, "Storing command whitelist into the state", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommandWhitelistResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *CommandWhitelistResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diags := data.payload(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateCommandWhitelist(r.connector, data.ID.ValueString(), payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update command whitelist, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CommandWhitelistResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *CommandWhitelistResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := deleteCommandWhitelist(r.connector, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete command whitelist, got error: %s", err))
+		return
+	}
+}
+
+func (r *CommandWhitelistResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.importState(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCommandWhitelistResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_command_whitelist", "id", func(connector restapi.Connector, id string) error {
+			_, err := getCommandWhitelist(connector, id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCommandWhitelistResourceConfig(name, `"systemctl status *", "journalctl *"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_command_whitelist.test", "name", name),
+					resource.TestCheckResourceAttr("privx_command_whitelist.test", "comment", "Read-only service commands"),
+					resource.TestCheckResourceAttr("privx_command_whitelist.test", "type", "GLOB"),
+					resource.TestCheckResourceAttr("privx_command_whitelist.test", "whitelist_patterns.#", "2"),
+					resource.TestCheckResourceAttr("privx_command_whitelist.test", "whitelist_patterns.0", "systemctl status *"),
+					resource.TestCheckResourceAttrSet("privx_command_whitelist.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_command_whitelist.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccCommandWhitelistResourceConfig(name, `"systemctl status *"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_command_whitelist.test", "whitelist_patterns.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccCommandWhitelistEvaluationDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCommandWhitelistResourceConfig(name, `"systemctl status *"`) + `
+data "privx_command_whitelist_evaluation" "test" {
+  whitelist_id   = privx_command_whitelist.test.id
+  rshell_variant = "bash"
+  commands       = ["systemctl status sshd", "systemctl restart sshd"]
+}
+
+data "privx_command_whitelist_evaluation" "patterns" {
+  type               = "REGEX"
+  whitelist_patterns = ["ls( -l)?"]
+  rshell_variant     = "posix"
+  commands           = ["ls", "ls -l", "ls -la"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.test", "whitelist_pattern_results.#", "1"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.test", "command_results.#", "2"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.test", "command_results.0.allowed", "true"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.test", "command_results.1.allowed", "false"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.test", "allowed_commands.#", "1"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.test", "allowed_commands.0", "systemctl status sshd"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.test", "denied_commands.0", "systemctl restart sshd"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.patterns", "allowed_commands.#", "2"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.patterns", "denied_commands.#", "1"),
+					resource.TestCheckResourceAttr("data.privx_command_whitelist_evaluation.patterns", "denied_commands.0", "ls -la"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCommandWhitelistResourceConfig(name, patterns string) string {
+	return fmt.Sprintf(`
+resource "privx_command_whitelist" "test" {
+  name               = %[1]q
+  comment            = "Read-only service commands"
+  whitelist_patterns = [%[2]s]
+}
+`, name, patterns)
+}
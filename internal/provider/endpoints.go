@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-privx/internal/client"
+)
+
+// EndpointModel describes a named PrivX endpoint of the provider. Unset
+// attributes default to the provider ones.
+type EndpointModel struct {
+	APIBaseURL        types.String `tfsdk:"api_base_url"`
+	APIBearerToken    types.String `tfsdk:"api_bearer_token"`
+	APIClientID       types.String `tfsdk:"api_client_id"`
+	APIClientSecret   types.String `tfsdk:"api_client_secret"`
+	OAuthClientID     types.String `tfsdk:"api_oauth_client_id"`
+	OAuthClientSecret types.String `tfsdk:"api_oauth_client_secret"`
+	CredentialProcess types.String `tfsdk:"credential_process"`
+}
+
+// endpointSettings are the resolved connection settings of a PrivX endpoint.
+type endpointSettings struct {
+	apiBaseURL        string
+	bearerToken       string
+	apiClientID       string
+	apiClientSecret   string
+	oauthClientID     string
+	oauthClientSecret string
+	credentialProcess string
+}
+
+func endpointsAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		MarkdownDescription: "Named PrivX endpoints, selected with the `endpoint` attribute of resources and data sources. Unset attributes of an endpoint default to the provider ones",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"api_base_url": schema.StringAttribute{
+					MarkdownDescription: "PrivX API Base URL",
+					Optional:            true,
+				},
+				"api_bearer_token": schema.StringAttribute{
+					MarkdownDescription: "PrivX bearer token",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"api_client_id": schema.StringAttribute{
+					MarkdownDescription: "PrivX API client ID",
+					Optional:            true,
+				},
+				"api_client_secret": schema.StringAttribute{
+					MarkdownDescription: "PrivX API client secret",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"api_oauth_client_id": schema.StringAttribute{
+					MarkdownDescription: "PrivX API OAuth client ID",
+					Optional:            true,
+				},
+				"api_oauth_client_secret": schema.StringAttribute{
+					MarkdownDescription: "PrivX API OAuth client secret",
+					Optional:            true,
+					Sensitive:           true,
+				},
+				"credential_process": schema.StringAttribute{
+					MarkdownDescription: "Command printing the PrivX credentials of the endpoint, see the provider `credential_process`",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+// settings returns the settings of the endpoint, defaulting to the provider
+// ones.
+func (m EndpointModel) settings(defaults endpointSettings) endpointSettings {
+	s := defaults
+	for _, a := range []struct {
+		value      types.String
+		setting    *string
+		credential bool
+	}{
+		{m.APIBaseURL, &s.apiBaseURL, false},
+		{m.APIBearerToken, &s.bearerToken, true},
+		{m.APIClientID, &s.apiClientID, true},
+		{m.APIClientSecret, &s.apiClientSecret, true},
+		{m.OAuthClientID, &s.oauthClientID, true},
+		{m.OAuthClientSecret, &s.oauthClientSecret, true},
+	} {
+		if !a.value.IsNull() && !a.value.IsUnknown() {
+			*a.setting = a.value.ValueString()
+			// The credentials of the endpoint replace the credential
+			// process of the provider
+			if a.credential {
+				s.credentialProcess = ""
+			}
+		}
+	}
+	if !m.CredentialProcess.IsNull() && !m.CredentialProcess.IsUnknown() {
+		s.credentialProcess = m.CredentialProcess.ValueString()
+	}
+	return s
+}
+
+// validate checks that the settings are complete. attribute returns the
+// path of the attribute of a setting.
+func (s endpointSettings) validate(attribute func(name string) path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if s.apiBaseURL == "" {
+		diags.AddAttributeError(
+			attribute("api_base_url"),
+			"Missing PrivX API base URL",
+			"The provider cannot create the PrivX API client as there is a missing or empty value for the PrivX API base URL. "+
+				"Set the host value in the configuration or use the PRIVX_API_BASE_URL environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	if s.bearerToken == "" && s.credentialProcess == "" {
+		if s.apiClientID == "" {
+			diags.AddAttributeError(
+				attribute("api_client_id"),
+				"Missing PrivX API client ID",
+				"The provider cannot create the PrivX API client as there is a missing or empty value for the PrivX API client ID. "+
+					"Set the username value in the configuration or use the PRIVX_API_CLIENT_ID environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if s.apiClientSecret == "" {
+			diags.AddAttributeError(
+				attribute("api_client_secret"),
+				"Missing PrivX API client secret",
+				"The provider cannot create the PrivX API client as there is a missing or empty value for the PrivX API client secret. "+
+					"Set the password value in the configuration or use the PRIVX_API_CLIENT_SECRET environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if s.oauthClientID == "" {
+			diags.AddAttributeError(
+				attribute("api_oauth_client_id"),
+				"Missing PrivX OAuth client ID",
+				"The provider cannot create the PrivX API client as there is a missing or empty value for the PrivX OAuth client ID. "+
+					"Set the username value in the configuration or use the PRIVX_API_OAUTH_ID environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if s.oauthClientSecret == "" {
+			diags.AddAttributeError(
+				attribute("api_oauth_client_secret"),
+				"Missing PrivX API client secret",
+				"The provider cannot create the PrivX API client as there is a missing or empty value for the PrivX OAuth client secret. "+
+					"Set the password value in the configuration or use the PRIVX_API_OAUTH_SECRET environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+	}
+	return diags
+}
+
+// connect returns a function connecting to the endpoint.
+func (s endpointSettings) connect(options func() []client.Option) func() (*restapi.Connector, error) {
+	return func() (*restapi.Connector, error) {
+		if s.credentialProcess != "" {
+			return client.NewCredentialProcessConnector(s.apiBaseURL, s.credentialProcess, options()...)
+		}
+		return client.NewConnector(s.apiBaseURL, s.bearerToken, s.apiClientID, s.apiClientSecret, s.oauthClientID, s.oauthClientSecret, options()...)
+	}
+}
+
+// endpointDescription documents the endpoint attribute of resources and
+// data sources.
+const endpointDescription = "Name of the provider endpoint the object is managed through (Defaults to the provider `default_endpoint`)"
+
+func endpointResourceAttribute() resourceschema.StringAttribute {
+	return resourceschema.StringAttribute{
+		MarkdownDescription: endpointDescription,
+		Optional:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func endpointDataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		MarkdownDescription: endpointDescription,
+		Optional:            true,
+	}
+}
+
+// endpoints picks the PrivX connector of resources and data sources from
+// their endpoint attribute. Resources and data sources are instantiated for
+// each request, so the clients bound to a connector only serve that request.
+type endpoints struct {
+	connectors *client.Connectors
+}
+
+// configure stores the connectors the provider configured.
+func (e *endpoints) configure(providerData any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	connectors, ok := providerData.(*client.Connectors)
+	if !ok {
+		diags.AddError(
+			"Unexpected Configure Type",
+			fmt.Sprintf("Expected *client.Connectors, got: %T. Please report this issue to the provider developers.", providerData),
+		)
+		return diags
+	}
+	e.connectors = connectors
+	return diags
+}
+
+// connector returns the connector of the endpoint.
+func (e *endpoints) connector(endpoint types.String) (restapi.Connector, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	connector, err := e.connectors.Connector(endpoint.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("endpoint"), "Unable to connect to PrivX",
+			fmt.Sprintf("Unable to connect to the PrivX endpoint, got error: %s", err))
+		return nil, diags
+	}
+	return *connector, diags
+}
+
+// importState imports the object of the ID into the attribute. The ID can
+// be prefixed with the name of the endpoint of the object, as in
+// `prod:<id>`.
+func (e *endpoints) importState(ctx context.Context, attribute path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, rest, ok := strings.Cut(req.ID, ":"); ok && name != "" && e.connectors != nil && e.connectors.Has(name) {
+		id = rest
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("endpoint"), name)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, attribute, id)...)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEndpointSettings(t *testing.T) {
+	defaults := endpointSettings{
+		apiBaseURL:        "https://privx.example.com",
+		credentialProcess: "privx-credentials",
+	}
+
+	// An endpoint overriding the base URL only keeps the credential
+	// process of the provider
+	s := EndpointModel{
+		APIBaseURL: types.StringValue("https://staging.privx.example.com"),
+	}.settings(defaults)
+	if s.apiBaseURL != "https://staging.privx.example.com" {
+		t.Errorf("expected the endpoint base URL, got %q", s.apiBaseURL)
+	}
+	if s.credentialProcess != defaults.credentialProcess {
+		t.Errorf("expected the provider credential process, got %q", s.credentialProcess)
+	}
+	if diags := s.validate(func(string) path.Path { return path.Empty() }); diags.HasError() {
+		t.Errorf("expected complete settings, got %v", diags)
+	}
+
+	// Endpoint credentials replace the credential process of the provider
+	s = EndpointModel{
+		APIBearerToken: types.StringValue("token"),
+	}.settings(defaults)
+	if s.credentialProcess != "" {
+		t.Errorf("expected no credential process, got %q", s.credentialProcess)
+	}
+	if s.bearerToken != "token" {
+		t.Errorf("expected the endpoint bearer token, got %q", s.bearerToken)
+	}
+}
@@ -3,11 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/authorizer"
 	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +23,7 @@ func NewExtenderConfigDataSource() datasource.DataSource {
 
 // ExtenderConfigDataSource defines the DataSource implementation.
 type ExtenderConfigDataSource struct {
+	endpoints
 	client    *authorizer.Client
 	connector *restapi.Connector
 }
@@ -29,6 +32,7 @@ type ExtenderConfigDataSource struct {
 type ExtenderConfigDataSourceModel struct {
 	TrustedClientID types.String `tfsdk:"trusted_client_id"`
 	ExtenderConfig  types.String `tfsdk:"extender_config"`
+	Endpoint        types.String `tfsdk:"endpoint"`
 }
 
 func (r *ExtenderConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -40,6 +44,7 @@ func (r *ExtenderConfigDataSource) Schema(ctx context.Context, req datasource.Sc
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "ExtenderConfig DataSource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"trusted_client_id": schema.StringAttribute{
 				MarkdownDescription: "ExtenderConfig ID",
 				Required:            true,
@@ -58,22 +63,22 @@ func (r *ExtenderConfigDataSource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (r *ExtenderConfigDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.connector = connector
-
-	r.client = authorizer.New(*connector)
+	r.connector = &connector
+	r.client = authorizer.New(connector)
+	return diags
 }
 
 func (r *ExtenderConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -86,6 +91,11 @@ func (r *ExtenderConfigDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	DownloadHandle, err := r.client.ExtenderConfigDownloadHandle(data.TrustedClientID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get extender download sessionid, got error: %s", err))
@@ -100,7 +110,7 @@ func (r *ExtenderConfigDataSource) Read(ctx context.Context, req datasource.Read
 	data.ExtenderConfig = types.StringValue(extender_config)
 
 	tflog.Debug(ctx, "Storing ExtenderConfig type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -3,11 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/userstore"
-	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +22,7 @@ func NewExtenderDataSource() datasource.DataSource {
 
 // ExtenderDataSource defines the DataSource implementation.
 type ExtenderDataSource struct {
+	endpoints
 	client *userstore.UserStore
 }
 
@@ -37,6 +39,7 @@ type ExtenderDataSourceModel struct {
 	ExtenderAddress types.List   `tfsdk:"extender_address"`
 	Subnets         types.List   `tfsdk:"subnets"`
 	Registered      types.Bool   `tfsdk:"registered"`
+	Endpoint        types.String `tfsdk:"endpoint"`
 }
 
 func (r *ExtenderDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -48,6 +51,7 @@ func (r *ExtenderDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Extender DataSource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Extender ID",
 				Computed:            true,
@@ -106,20 +110,21 @@ func (r *ExtenderDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (r *ExtenderDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = userstore.New(*connector)
+	r.client = userstore.New(connector)
+	return diags
 }
 
 func (r *ExtenderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -132,12 +137,30 @@ func (r *ExtenderDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	extender, err := r.client.TrustedClient(data.ID.ValueString())
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trustedClients, err := r.client.TrustedClients()
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extender, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read trustedClient list, got error: %s", err))
+		return
+	}
+
+	var extender *userstore.TrustedClient
+	for i, client := range trustedClients {
+		if client.Name == data.Name.ValueString() && client.Type == userstore.ClientExtender {
+			extender = &trustedClients[i]
+			break
+		}
+	}
+	if extender == nil {
+		resp.Diagnostics.AddError("Extender Error", fmt.Sprintf("Unable to find extender named %s", data.Name.ValueString()))
 		return
 	}
 
+	data.ID = types.StringValue(extender.ID)
 	data.Name = types.StringValue(extender.Name)
 	data.Secret = types.StringValue(extender.Secret)
 	data.Registered = types.BoolValue(extender.Registered)
@@ -163,7 +186,7 @@ func (r *ExtenderDataSource) Read(ctx context.Context, req datasource.ReadReques
 	data.ExtenderAddress = extenderAddress
 
 	tflog.Debug(ctx, "Storing extender type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
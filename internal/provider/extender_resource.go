@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/userstore"
-	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -27,6 +29,7 @@ func NewExtenderResource() resource.Resource {
 
 // ExtenderResource defines the resource implementation.
 type ExtenderResource struct {
+	endpoints
 	client *userstore.UserStore
 }
 
@@ -43,6 +46,7 @@ type ExtenderResourceModel struct {
 	Subnets         types.List   `tfsdk:"subnets"`
 	Registered      types.Bool   `tfsdk:"registered"`
 	AccessGroupId   types.String `tfsdk:"access_group_id"`
+	Endpoint        types.String `tfsdk:"endpoint"`
 }
 
 func (r *ExtenderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,6 +58,7 @@ func (r *ExtenderResource) Schema(ctx context.Context, req resource.SchemaReques
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Extender resource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Extender ID",
 				Computed:            true,
@@ -124,20 +129,21 @@ func (r *ExtenderResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *ExtenderResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = userstore.New(*connector)
+	r.client = userstore.New(connector)
+	return diags
 }
 
 func (r *ExtenderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -150,8 +156,13 @@ func (r *ExtenderResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Loaded extender type data", map[string]interface{}{
-		"data": fmt.Sprintf("%+v", data),
+		"data": utils.Redacted(data),
 	})
 
 	var extenderPermissionPayload []string
@@ -183,7 +194,7 @@ func (r *ExtenderResource) Create(ctx context.Context, req resource.CreateReques
 		RoutingPrefix:   data.RoutingPrefix.ValueString(),
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("userstore.Extender model used: %+v", extender))
+	tflog.Debug(ctx, "userstore.Extender model used: "+utils.Redacted(extender))
 
 	extenderID, err := r.client.CreateTrustedClient(extender)
 
@@ -235,7 +246,17 @@ func (r *ExtenderResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	extender, err := r.client.TrustedClient(data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "extender not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read extender, got error: %s", err))
 		return
@@ -245,6 +266,7 @@ func (r *ExtenderResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.Registered = types.BoolValue(extender.Registered)
 	data.Enabled = types.BoolValue(extender.Enabled)
 	data.RoutingPrefix = types.StringValue(extender.RoutingPrefix)
+	data.AccessGroupId = types.StringValue(extender.AccessGroupId)
 
 	subnets, diags := types.ListValueFrom(ctx, data.Subnets.ElementType(ctx), extender.Subnets)
 	if diags.HasError() {
@@ -265,7 +287,7 @@ func (r *ExtenderResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.ExtenderAddress = extenderAddress
 
 	tflog.Debug(ctx, "Storing extender type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -281,6 +303,11 @@ func (r *ExtenderResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var extenderAddressPayload []string
 	if len(data.ExtenderAddress.Elements()) > 0 {
 		resp.Diagnostics.Append(data.ExtenderAddress.ElementsAs(ctx, &extenderAddressPayload, false)...)
@@ -307,7 +334,7 @@ func (r *ExtenderResource) Update(ctx context.Context, req resource.UpdateReques
 		RoutingPrefix:   data.RoutingPrefix.ValueString(),
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("userstore.Extender model used: %+v", extender))
+	tflog.Debug(ctx, "userstore.Extender model used: "+utils.Redacted(extender))
 
 	err := r.client.UpdateTrustedClient(
 		data.ID.ValueString(),
@@ -331,6 +358,11 @@ func (r *ExtenderResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteTrustedClient(data.ID.ValueString())
 
 	if err != nil {
@@ -340,5 +372,5 @@ func (r *ExtenderResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *ExtenderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.importState(ctx, path.Root("id"), req, resp)
 }
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccExtenderResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_extender", "id", func(connector restapi.Connector, id string) error {
+			_, err := userstore.New(connector).TrustedClient(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccExtenderResourceConfig(name, true, `"10.0.0.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_extender.test", "name", name),
+					resource.TestCheckResourceAttr("privx_extender.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("privx_extender.test", "registered", "false"),
+					resource.TestCheckResourceAttr("privx_extender.test", "subnets.#", "1"),
+					resource.TestCheckResourceAttr("privx_extender.test", "permissions.0", "privx-extender"),
+					resource.TestCheckResourceAttrSet("privx_extender.test", "access_group_id"),
+					resource.TestCheckResourceAttrSet("privx_extender.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "privx_extender.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"web_proxy_address", "web_proxy_port"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccExtenderResourceConfig(name, false, `"10.0.0.0/24", "10.0.1.0/24"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_extender.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("privx_extender.test", "subnets.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccExtenderDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExtenderResourceConfig(name, true, `"10.0.0.0/24"`) + `
+data "privx_extender" "test" {
+  name = privx_extender.test.name
+}
+
+data "privx_extender_config" "test" {
+  trusted_client_id = privx_extender.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.privx_extender.test", "id", "privx_extender.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_extender.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("data.privx_extender.test", "routing_prefix", "extender"),
+					resource.TestCheckResourceAttr("data.privx_extender.test", "subnets.#", "1"),
+					resource.TestCheckResourceAttrSet("data.privx_extender.test", "secret"),
+					resource.TestCheckResourceAttrSet("data.privx_extender_config.test", "extender_config"),
+				),
+			},
+		},
+	})
+}
+
+func testAccExtenderResourceConfig(name string, enabled bool, subnets string) string {
+	return fmt.Sprintf(`
+resource "privx_extender" "test" {
+  name             = %[1]q
+  enabled          = %[2]t
+  routing_prefix   = "extender"
+  extender_address = ["10.0.0.1"]
+  subnets          = [%[3]s]
+}
+`, name, enabled, subnets)
+}
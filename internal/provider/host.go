@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"net/url"
+
+	"github.com/SSHcom/privx-sdk-go/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+)
+
+// privxHost is a hoststore.Host along with the attributes privx-sdk-go does
+// not model yet.
+type privxHost struct {
+	hoststore.Host
+	Services                []hostService     `json:"services,omitempty"`
+	Principals              []hostPrincipal   `json:"principals,omitempty"`
+	PasswordRotationEnabled bool              `json:"password_rotation_enabled"`
+	PasswordRotation        *passwordRotation `json:"password_rotation,omitempty"`
+	CertificateTemplate     string            `json:"certificate_template,omitempty"`
+	HostCertificateRaw      string            `json:"host_certificate_raw,omitempty"`
+}
+
+type hostService struct {
+	hoststore.Service
+	UseForPasswordRotation bool `json:"use_for_password_rotation"`
+}
+
+type hostPrincipal struct {
+	hoststore.Principal
+	Rotate                 bool                 `json:"rotate"`
+	UseForPasswordRotation bool                 `json:"use_for_password_rotation"`
+	Applications           []hostApplication    `json:"applications"`
+	ServiceOptions         *serviceOptions      `json:"service_options,omitempty"`
+	CommandRestrictions    *commandRestrictions `json:"command_restrictions,omitempty"`
+}
+
+// hostApplication is an application a principal may launch on the host,
+// RemoteApp-style. privx-sdk-go still models applications as names only.
+type hostApplication struct {
+	Name             string `json:"name"`
+	Application      string `json:"application"`
+	Arguments        string `json:"arguments"`
+	WorkingDirectory string `json:"working_directory"`
+}
+
+// serviceOptions are the features of the services of the host a principal
+// is allowed to use. PrivX enables the features it is not given.
+type serviceOptions struct {
+	SSH *hoststore.SSHService `json:"ssh,omitempty"`
+	RDP *hoststore.RDPService `json:"rdp,omitempty"`
+	Web *hoststore.WebService `json:"web,omitempty"`
+}
+
+var (
+	defaultSSHService = hoststore.SSHService{Shell: true, FileTransfer: true, Exec: true, Tunnels: true, Xeleven: true, Other: true}
+	defaultRDPService = hoststore.RDPService{FileTransfer: true, Audio: true, Clipboard: true}
+	defaultWebService = hoststore.WebService{FileTransfer: true, Audio: true, Clipboard: true}
+)
+
+// withDefaults returns the options with the services PrivX was not given
+// options for set to their defaults.
+func (o *serviceOptions) withDefaults() *serviceOptions {
+	options := serviceOptions{}
+	if o != nil {
+		options = *o
+	}
+	if options.SSH == nil {
+		ssh := defaultSSHService
+		options.SSH = &ssh
+	}
+	if options.RDP == nil {
+		rdp := defaultRDPService
+		options.RDP = &rdp
+	}
+	if options.Web == nil {
+		web := defaultWebService
+		options.Web = &web
+	}
+	return &options
+}
+
+// commandRestrictions restrict the commands a principal runs to the patterns
+// of whitelists, through a restricted shell.
+type commandRestrictions struct {
+	Enabled          bool             `json:"enabled"`
+	DefaultWhitelist whitelistHandle  `json:"default_whitelist"`
+	Whitelists       []whitelistGrant `json:"whitelists"`
+	RShellVariant    string           `json:"rshell_variant,omitempty"`
+	Banner           string           `json:"banner"`
+	AllowNoMatch     bool             `json:"allow_no_match"`
+	AuditMatch       bool             `json:"audit_match"`
+	AuditNoMatch     bool             `json:"audit_no_match"`
+}
+
+type whitelistHandle struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// whitelistGrant gives the members of roles the commands of a whitelist.
+type whitelistGrant struct {
+	Whitelist whitelistHandle     `json:"whitelist"`
+	Roles     []rolestore.RoleRef `json:"roles"`
+}
+
+// isEmpty tells whether the restrictions were never set: PrivX returns
+// disabled restrictions rather than none.
+func (c *commandRestrictions) isEmpty() bool {
+	return c == nil || !c.Enabled && c.DefaultWhitelist.ID == "" && len(c.Whitelists) == 0 &&
+		c.RShellVariant == "" && c.Banner == "" && !c.AllowNoMatch && !c.AuditMatch && !c.AuditNoMatch
+}
+
+// passwordRotation holds the settings PrivX rotates the passwords of the
+// principals of a host with.
+type passwordRotation struct {
+	UseMainAccount   bool   `json:"use_main_account"`
+	OperatingSystem  string `json:"operating_system"`
+	WinRMAddress     string `json:"winrm_address"`
+	WinRMPort        int    `json:"winrm_port"`
+	Protocol         string `json:"protocol"`
+	PasswordPolicyID string `json:"password_policy_id"`
+	ScriptTemplateID string `json:"script_template_id"`
+}
+
+// The host store calls below go through the connector directly, as
+// hoststore.Host drops the attributes of privxHost, and fails to decode
+// the applications of principals.
+
+func createHost(connector restapi.Connector, host *privxHost) (string, error) {
+	var object struct {
+		ID string `json:"id"`
+	}
+
+	_, err := connector.
+		URL("/host-store/api/v1/hosts").
+		Post(host, &object)
+
+	return object.ID, err
+}
+
+func getHost(connector restapi.Connector, hostID string) (*privxHost, error) {
+	host := &privxHost{}
+
+	_, err := connector.
+		URL("/host-store/api/v1/hosts/%s", url.PathEscape(hostID)).
+		Get(host)
+
+	return host, err
+}
+
+// searchHosts returns all the hosts matching searchObject, walking through
+// the result pages of the host store search API.
+func searchHosts(connector restapi.Connector, searchObject *hoststore.HostSearchObject) ([]privxHost, error) {
+	const pageSize = 100
+
+	var hosts []privxHost
+	for offset := 0; ; offset += pageSize {
+		var page struct {
+			Items []privxHost `json:"items"`
+		}
+		_, err := connector.
+			URL("/host-store/api/v1/hosts/search").
+			Query(&hoststore.Params{Offset: offset, Limit: pageSize, Sortkey: "id", Sortdir: "ASC"}).
+			Post(searchObject, &page)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, page.Items...)
+		if len(page.Items) < pageSize {
+			return hosts, nil
+		}
+	}
+}
+
+func updateHost(connector restapi.Connector, hostID string, host *privxHost) error {
+	_, err := connector.
+		URL("/host-store/api/v1/hosts/%s", url.PathEscape(hostID)).
+		Put(host)
+
+	return err
+}
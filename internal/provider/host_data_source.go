@@ -3,11 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/hoststore"
 	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,7 +28,8 @@ func NewHostDataSource() datasource.DataSource {
 
 // HostDataSource defines the data source implementation.
 type HostDataSource struct {
-	client *hoststore.HostStore
+	endpoints
+	connector restapi.Connector
 }
 
 // HostDataSourceModel describes the data source data model.
@@ -31,28 +39,40 @@ type (
 		V types.String `tfsdk:"v"`
 	}
 	ApplicationDataSourceModel struct {
-		Name types.String `tfsdk:"name"`
-		/* FIXME: Not implemented in privx-sdk-go v1.29.0
+		Name             types.String `tfsdk:"name"`
 		Application      types.String `tfsdk:"application"`
 		Arguments        types.String `tfsdk:"arguments"`
 		WorkingDirectory types.String `tfsdk:"working_directory"`
-		*/
+	}
+
+	WhitelistGrantDataSourceModel struct {
+		Whitelist WhitelistModel `tfsdk:"whitelist"`
+		Roles     []RoleRefModel `tfsdk:"roles"`
+	}
+
+	CommandRestrictionsDataSourceModel struct {
+		RShellVariant    types.String                    `tfsdk:"rshell_variant"`
+		Banner           types.String                    `tfsdk:"banner"`
+		Enabled          types.Bool                      `tfsdk:"enabled"`
+		AllowNoMatch     types.Bool                      `tfsdk:"allow_no_match"`
+		AuditMatch       types.Bool                      `tfsdk:"audit_match"`
+		AuditNoMatch     types.Bool                      `tfsdk:"audit_no_match"`
+		DefaultWhitelist WhitelistModel                  `tfsdk:"default_whitelist"`
+		Whitelists       []WhitelistGrantDataSourceModel `tfsdk:"whitelists"`
 	}
 
 	PrincipalDataSourceModel struct {
 		ID             types.String                 `tfsdk:"principal"`
 		Passphrase     types.String                 `tfsdk:"passphrase"`
-		Source         types.String                 `tfsdk:"Source"`
+		Source         types.String                 `tfsdk:"source"`
 		UseUserAccount types.Bool                   `tfsdk:"use_user_account"`
 		Roles          []RoleRefModel               `tfsdk:"roles"`
 		Applications   []ApplicationDataSourceModel `tfsdk:"applications"`
 
-		/* FIXME: Not implemented in privx-sdk-go v1.29.0
-		Rotate                 types.Bool               `tfsdk:"rotate"`
-		UseForPasswordRotation types.Bool               `tfsdk:"use_for_password_rotation"`
-		ServiceOptions         ServiceOptionsModel      `tfsdk:"service_options"`
-		CommandRestrictions    CommandRestrictionsModel `tfsdk:"command_restrictions"`
-		*/
+		Rotate                 types.Bool                          `tfsdk:"rotate"`
+		UseForPasswordRotation types.Bool                          `tfsdk:"use_for_password_rotation"`
+		ServiceOptions         *ServiceOptionsModel                `tfsdk:"service_options"`
+		CommandRestrictions    *CommandRestrictionsDataSourceModel `tfsdk:"command_restrictions"`
 	}
 
 	HostDataSourceModel struct {
@@ -79,6 +99,7 @@ type (
 		Audit               types.Bool                 `tfsdk:"audit_enabled"`
 		Scope               types.Set                  `tfsdk:"scope"`
 		Tags                types.Set                  `tfsdk:"tags"`
+		Address             types.String               `tfsdk:"address"`
 		Addresses           types.Set                  `tfsdk:"addresses"`
 		Services            []ServiceModel             `tfsdk:"services"`
 		Principals          []PrincipalDataSourceModel `tfsdk:"principals"`
@@ -89,12 +110,13 @@ type (
 		UpdatedBy types.String  `tfsdk:"updated_by"`
 		Status    []StatusModel `tfsdk:"status"`
 
-		/* FIXME: Not implemented in privx-sdk-go v1.29.0
-		CertificateTemplate     types.String          `tfsdk:"certificate_template"`
-		HostCertificateRaw      types.String          `tfsdk:"host_certificate_raw"`
-		PasswordRotationEnabled types.Bool            `tfsdk:"password_rotation_enabled"`
-		PasswordRotation        PasswordRotationModel `tfsdk:"password_rotation"`
-		*/
+		PasswordRotationEnabled types.Bool             `tfsdk:"password_rotation_enabled"`
+		PasswordRotation        *PasswordRotationModel `tfsdk:"password_rotation"`
+
+		CertificateTemplate types.String `tfsdk:"certificate_template"`
+		HostCertificateRaw  types.String `tfsdk:"host_certificate_raw"`
+
+		Endpoint types.String `tfsdk:"endpoint"`
 	}
 )
 
@@ -105,10 +127,12 @@ func (d *HostDataSource) Metadata(ctx context.Context, req datasource.MetadataRe
 func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Example data source",
+		MarkdownDescription: "Host data source. The host is looked up by `id`, `common_name`, `external_id`, `instance_id` or `address`; exactly one host must match all the given criteria.",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Host ID",
+				Optional:            true,
 				Computed:            true,
 			},
 			"access_group_id": schema.StringAttribute{
@@ -117,10 +141,12 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 			},
 			"external_id": schema.StringAttribute{
 				MarkdownDescription: "The equipment ID from the originating equipment store",
+				Optional:            true,
 				Computed:            true,
 			},
 			"instance_id": schema.StringAttribute{
 				MarkdownDescription: "The instance ID from the originating cloud service (searchable by keyword)",
+				Optional:            true,
 				Computed:            true,
 			},
 			"source_id": schema.StringAttribute{
@@ -129,6 +155,7 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 			},
 			"common_name": schema.StringAttribute{
 				MarkdownDescription: "X.500 Common name (searchable by keyword)",
+				Optional:            true,
 				Computed:            true,
 			},
 			"created": schema.StringAttribute{
@@ -183,12 +210,10 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "A comment describing the host",
 				Computed:            true,
 			},
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"host_certificate_raw": schema.StringAttribute{
-				MarkdownDescription: "Host certificate, used to verify that the target host is the correct one.",
+				MarkdownDescription: "Host certificate, used to verify that the target host is the correct one. PEM encoded",
 				Computed:            true,
 			},
-			*/
 			"disabled": schema.StringAttribute{
 				MarkdownDescription: `disabled ("BY_ADMIN" | "BY_LISCENCE" | "false")`,
 				Computed:            true,
@@ -219,17 +244,19 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Host tags",
 				Computed:            true,
 			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "One of the host addresses, used to look the host up",
+				Optional:            true,
+			},
 			"addresses": schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "Host addresses",
 				Computed:            true,
 			},
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"certificate_template": schema.StringAttribute{
 				MarkdownDescription: "Name of the certificate template used for certificate authentication for this host",
 				Computed:            true,
 			},
-			*/
 			"status": schema.SetNestedAttribute{
 				MarkdownDescription: "Status",
 				Computed:            true,
@@ -275,12 +302,10 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 							MarkdownDescription: "Service port",
 							Computed:            true,
 						},
-						/*
-							"use_for_password_rotation": schema.BoolAttribute{
-								MarkdownDescription: "if service SSH, informs whether this service is used to rotate password",
-								Computed:            true,
-							},
-						*/
+						"use_for_password_rotation": schema.BoolAttribute{
+							MarkdownDescription: "if service SSH, informs whether this service is used to rotate password",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -293,16 +318,14 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 							MarkdownDescription: "The account name",
 							Computed:            true,
 						},
-						/* FIXME: Not implemented in privx-sdk-go v1.29.0
 						"rotate": schema.BoolAttribute{
 							MarkdownDescription: "Rotate password of this account",
 							Computed:            true,
 						},
 						"use_for_password_rotation": schema.BoolAttribute{
-							MarkdownDescription: "marks account to be used as the account through which password rotation takes place, when flag use_main_account set in rotation_metadata",
+							MarkdownDescription: "marks account to be used as the account through which password rotation takes place, when flag use_main_account set in password_rotation",
 							Computed:            true,
 						},
-						*/
 						"use_user_account": schema.BoolAttribute{
 							MarkdownDescription: "Use user account as host principal name",
 							Computed:            true,
@@ -338,25 +361,26 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"name": schema.StringAttribute{
-										Computed: true,
+										MarkdownDescription: "Application name",
+										Computed:            true,
 									},
-									/* FIXME: Not implemented in privx-sdk-go v1.29.0
 									"application": schema.StringAttribute{
-										Computed: true,
+										MarkdownDescription: "Path of the application executable on the host",
+										Computed:            true,
 									},
 									"arguments": schema.StringAttribute{
-										Computed: true,
+										MarkdownDescription: "Command line arguments of the application",
+										Computed:            true,
 									},
 									"working_directory": schema.StringAttribute{
-										Computed: true,
+										MarkdownDescription: "Working directory of the application",
+										Computed:            true,
 									},
-									*/
 								},
 							},
 						},
-						/* FIXME: Not implemented in privx-sdk-go v1.29.0
 						"service_options": schema.SingleNestedAttribute{
-							MarkdownDescription: "Object for service options",
+							MarkdownDescription: "Service features allowed to the principal",
 							Computed:            true,
 							Attributes: map[string]schema.Attribute{
 								"ssh": schema.SingleNestedAttribute{
@@ -372,25 +396,25 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 											Computed:            true,
 										},
 										"exec": schema.BoolAttribute{
-											MarkdownDescription: "exec_channel",
+											MarkdownDescription: "Exec channel",
 											Computed:            true,
 										},
 										"tunnels": schema.BoolAttribute{
-											MarkdownDescription: "tunnels",
+											MarkdownDescription: "Tunnels",
 											Computed:            true,
 										},
 										"x11": schema.BoolAttribute{
-											MarkdownDescription: "x11",
+											MarkdownDescription: "X11 forwarding",
 											Computed:            true,
 										},
 										"other": schema.BoolAttribute{
-											MarkdownDescription: "other options",
+											MarkdownDescription: "Other channels",
 											Computed:            true,
 										},
 									},
 								},
 								"rdp": schema.SingleNestedAttribute{
-									MarkdownDescription: "SSH service options",
+									MarkdownDescription: "RDP service options",
 									Computed:            true,
 									Attributes: map[string]schema.Attribute{
 										"file_transfer": schema.BoolAttribute{
@@ -398,21 +422,17 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 											Computed:            true,
 										},
 										"audio": schema.BoolAttribute{
-											MarkdownDescription: "audio",
+											MarkdownDescription: "Audio",
 											Computed:            true,
 										},
 										"clipboard": schema.BoolAttribute{
-											MarkdownDescription: "clipboard",
-											Computed:            true,
-										},
-										"web": schema.BoolAttribute{
-											MarkdownDescription: "WEB service options",
+											MarkdownDescription: "Clipboard",
 											Computed:            true,
 										},
 									},
 								},
 								"web": schema.SingleNestedAttribute{
-									MarkdownDescription: "SSH service options",
+									MarkdownDescription: "Web service options",
 									Computed:            true,
 									Attributes: map[string]schema.Attribute{
 										"file_transfer": schema.BoolAttribute{
@@ -420,11 +440,11 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 											Computed:            true,
 										},
 										"audio": schema.BoolAttribute{
-											MarkdownDescription: "audio",
+											MarkdownDescription: "Audio",
 											Computed:            true,
 										},
 										"clipboard": schema.BoolAttribute{
-											MarkdownDescription: "clipboard",
+											MarkdownDescription: "Clipboard",
 											Computed:            true,
 										},
 									},
@@ -432,7 +452,7 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 							},
 						},
 						"command_restrictions": schema.SingleNestedAttribute{
-							MarkdownDescription: "Host services",
+							MarkdownDescription: "Restricted shell settings of the principal",
 							Computed:            true,
 							Attributes: map[string]schema.Attribute{
 								"enabled": schema.BoolAttribute{
@@ -462,7 +482,7 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 									Computed:            true,
 								},
 								"banner": schema.StringAttribute{
-									MarkdownDescription: "Computed banner displayed in SSH terminal",
+									MarkdownDescription: "Banner displayed in SSH terminal",
 									Computed:            true,
 								},
 								"allow_no_match": schema.BoolAttribute{
@@ -478,11 +498,13 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 									Computed:            true,
 								},
 								"whitelists": schema.SetNestedAttribute{
-									Computed: true,
+									MarkdownDescription: "Whitelists granted to the members of roles, on top of the default whitelist",
+									Computed:            true,
 									NestedObject: schema.NestedAttributeObject{
 										Attributes: map[string]schema.Attribute{
 											"whitelist": schema.SingleNestedAttribute{
-												Computed: true,
+												MarkdownDescription: "Whitelist handle",
+												Computed:            true,
 												Attributes: map[string]schema.Attribute{
 													"id": schema.StringAttribute{
 														MarkdownDescription: "Whitelist ID",
@@ -492,7 +514,6 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 														MarkdownDescription: "Whitelist name",
 														Computed:            true,
 													},
-
 													"deleted": schema.BoolAttribute{
 														MarkdownDescription: "Has whitelist been deleted, ignored in requests",
 														Computed:            true,
@@ -502,7 +523,6 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 											"roles": schema.SetNestedAttribute{
 												MarkdownDescription: "List of roles granting access to the whitelist",
 												Computed:            true,
-
 												NestedObject: schema.NestedAttributeObject{
 													Attributes: map[string]schema.Attribute{
 														"id": schema.StringAttribute{
@@ -521,17 +541,13 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 								},
 							},
 						},
-						*/
 					},
 				},
 			},
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"password_rotation_enabled": schema.BoolAttribute{
 				MarkdownDescription: "set, if there are accounts, in which passwords need to be rotated",
 				Computed:            true,
 			},
-			*/
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"password_rotation": schema.SingleNestedAttribute{
 				MarkdownDescription: "password rotation settings for host",
 				Computed:            true,
@@ -566,7 +582,6 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 					},
 				},
 			},
-			*/
 		},
 	}
 }
@@ -577,20 +592,111 @@ func (d *HostDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *HostDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating HostStore client", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	d.client = hoststore.New(*connector)
+	d.connector = connector
+	return diags
+}
+
+func (d HostDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("common_name"),
+			path.MatchRoot("external_id"),
+			path.MatchRoot("instance_id"),
+			path.MatchRoot("address"),
+		),
+	}
+}
+
+// lookupHosts returns the hosts matching all the lookup attributes set in data.
+func (d *HostDataSource) lookupHosts(data HostDataSourceModel) ([]privxHost, error) {
+	var candidates []privxHost
+	if !data.ID.IsNull() {
+		host, err := getHost(d.connector, data.ID.ValueString())
+		if client.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, *host)
+	} else {
+		searchObject := &hoststore.HostSearchObject{
+			ExternalID: data.ExternalID.ValueString(),
+			InstanceID: data.InstanceID.ValueString(),
+		}
+		if !data.Name.IsNull() {
+			searchObject.CommonName = []string{data.Name.ValueString()}
+		}
+		if !data.Address.IsNull() {
+			searchObject.Address = []string{data.Address.ValueString()}
+		}
+
+		hosts, err := searchHosts(d.connector, searchObject)
+		if err != nil {
+			return nil, err
+		}
+		candidates = hosts
+	}
+
+	// The search API also returns partial matches, keep exact ones only
+	var hosts []privxHost
+	for _, host := range candidates {
+		if !data.ExternalID.IsNull() && host.ExternalID != data.ExternalID.ValueString() {
+			continue
+		}
+		if !data.InstanceID.IsNull() && host.InstanceID != data.InstanceID.ValueString() {
+			continue
+		}
+		if !data.Name.IsNull() && host.Name != data.Name.ValueString() {
+			continue
+		}
+		if !data.Address.IsNull() && !hostHasAddress(host.Host, data.Address.ValueString()) {
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
+
+func hostHasAddress(host hoststore.Host, address string) bool {
+	for _, a := range host.Addresses {
+		if string(a) == address {
+			return true
+		}
+	}
+	return false
+}
+
+func hostLookupCriteria(data HostDataSourceModel) string {
+	var criteria []string
+	for name, value := range map[string]types.String{
+		"id":          data.ID,
+		"common_name": data.Name,
+		"external_id": data.ExternalID,
+		"instance_id": data.InstanceID,
+		"address":     data.Address,
+	} {
+		if !value.IsNull() {
+			criteria = append(criteria, fmt.Sprintf("%s=%q", name, value.ValueString()))
+		}
+	}
+	sort.Strings(criteria)
+	return strings.Join(criteria, ", ")
 }
 
 func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -603,12 +709,35 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	host, err := d.client.Host(data.ID.ValueString())
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, err := d.lookupHosts(data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read host, got error: %s", err))
 		return
 	}
 
+	if len(hosts) == 0 {
+		resp.Diagnostics.AddError("Host Not Found", fmt.Sprintf("No host matches %s", hostLookupCriteria(data)))
+		return
+	}
+	if len(hosts) > 1 {
+		var ids []string
+		for _, h := range hosts {
+			ids = append(ids, h.ID)
+		}
+		resp.Diagnostics.AddError("Multiple Hosts Found",
+			fmt.Sprintf("%d hosts match %s (%s), please narrow the lookup", len(hosts), hostLookupCriteria(data), strings.Join(ids, ", ")))
+		return
+	}
+
+	host := hosts[0]
+
+	data.ID = types.StringValue(host.ID)
+
 	data.AccessGroupID = types.StringValue(host.AccessGroupID)
 	data.ExternalID = types.StringValue(host.ExternalID)
 	data.InstanceID = types.StringValue(host.InstanceID)
@@ -629,8 +758,10 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	data.Comment = types.StringValue(host.Comment)
 	data.Disabled = types.StringValue(host.Disabled)
 	data.Deployable = types.BoolValue(host.Deployable)
+	data.CertificateTemplate = types.StringValue(host.CertificateTemplate)
+	data.HostCertificateRaw = types.StringValue(host.HostCertificateRaw)
 	data.Tofu = types.BoolValue(host.Tofu)
-	data.StandAlone = types.BoolValue(host.Tofu)
+	data.StandAlone = types.BoolValue(host.StandAlone)
 	data.Audit = types.BoolValue(host.Audit)
 
 	scope, diags := types.SetValueFrom(ctx, data.Scope.ElementType(ctx), host.Scope)
@@ -666,7 +797,8 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 			Scheme:  types.StringValue(string(s.Scheme)),
 			Address: types.StringValue(string(s.Address)),
 			Port:    types.Int64Value(int64(s.Port)),
-			// UseForPasswordRotation: types.StringValue(s.UseForPasswordRotation), // FIXME: Not implemented in privx-sdk-go v1.29.0
+
+			UseForPasswordRotation: types.BoolValue(s.UseForPasswordRotation),
 		})
 	}
 	data.Services = services
@@ -676,25 +808,31 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		var roles []RoleRefModel
 		for _, r := range p.Roles {
 			roles = append(roles, RoleRefModel{
-				ID: types.StringValue(r.ID),
+				ID:   types.StringValue(r.ID),
+				Name: types.StringValue(r.Name),
 			})
 		}
 		var applications []ApplicationDataSourceModel
 		for _, a := range p.Applications {
 			applications = append(applications, ApplicationDataSourceModel{
-				Name: types.StringValue(a),
+				Name:             types.StringValue(a.Name),
+				Application:      types.StringValue(a.Application),
+				Arguments:        types.StringValue(a.Arguments),
+				WorkingDirectory: types.StringValue(a.WorkingDirectory),
 			})
 		}
 		principals = append(principals, PrincipalDataSourceModel{
+			ID:             types.StringValue(p.ID),
 			Passphrase:     types.StringValue(p.Passphrase),
 			Source:         types.StringValue(string(p.Source)),
 			UseUserAccount: types.BoolValue(p.UseUserAccount),
-			// Rotate:     types.BoolValue(p.Rotate), // FIXME: Not implemented in privx-sdk-go v1.29.0
-			// UseForPasswordRotation:     types.BoolValue(p.UseForPasswordRotation), // FIXME: Not implemented in privx-sdk-go v1.29.0
-			// ServiceOptions: serviceOptions, // FIXME: Not implemented in privx-sdk-go v1.29.0
-			// CommandRestrictions: commandRestrictions, // FIXME: Not implemented in privx-sdk-go v1.29.0
-			Roles:        roles,
-			Applications: applications,
+			Rotate:         types.BoolValue(p.Rotate),
+
+			UseForPasswordRotation: types.BoolValue(p.UseForPasswordRotation),
+			ServiceOptions:         newServiceOptionsDataSourceModel(p.ServiceOptions),
+			CommandRestrictions:    newCommandRestrictionsDataSourceModel(p.CommandRestrictions),
+			Roles:                  roles,
+			Applications:           applications,
 		})
 	}
 	data.Principals = principals
@@ -707,9 +845,82 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 	data.PublicKeys = publickeys
 
+	data.PasswordRotationEnabled = types.BoolValue(host.PasswordRotationEnabled)
+	data.PasswordRotation = newPasswordRotationModel(host.PasswordRotation)
+
 	tflog.Debug(ctx, "Storing host type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// newServiceOptionsDataSourceModel returns the model of the service options
+// of a principal, PrivX defaults included.
+func newServiceOptionsDataSourceModel(options *serviceOptions) *ServiceOptionsModel {
+	options = options.withDefaults()
+
+	return &ServiceOptionsModel{
+		SSH: &SSHServiceModel{
+			Shell:        types.BoolValue(options.SSH.Shell),
+			FileTransfer: types.BoolValue(options.SSH.FileTransfer),
+			Exec:         types.BoolValue(options.SSH.Exec),
+			Tunnels:      types.BoolValue(options.SSH.Tunnels),
+			X11:          types.BoolValue(options.SSH.Xeleven),
+			Other:        types.BoolValue(options.SSH.Other),
+		},
+		RDP: &RDPServiceModel{
+			FileTransfer: types.BoolValue(options.RDP.FileTransfer),
+			Audio:        types.BoolValue(options.RDP.Audio),
+			Clipboard:    types.BoolValue(options.RDP.Clipboard),
+		},
+		Web: &RDPServiceModel{
+			FileTransfer: types.BoolValue(options.Web.FileTransfer),
+			Audio:        types.BoolValue(options.Web.Audio),
+			Clipboard:    types.BoolValue(options.Web.Clipboard),
+		},
+	}
+}
+
+// newWhitelistModel returns the model of a whitelist handle.
+func newWhitelistModel(whitelist whitelistHandle) WhitelistModel {
+	return WhitelistModel{
+		ID:      types.StringValue(whitelist.ID),
+		Name:    types.StringValue(whitelist.Name),
+		Deleted: types.BoolValue(whitelist.Deleted),
+	}
+}
+
+// newCommandRestrictionsDataSourceModel returns the model of the command
+// restrictions of a principal, nil when it has none.
+func newCommandRestrictionsDataSourceModel(restrictions *commandRestrictions) *CommandRestrictionsDataSourceModel {
+	if restrictions.isEmpty() {
+		return nil
+	}
+
+	var whitelists []WhitelistGrantDataSourceModel
+	for _, whitelist := range restrictions.Whitelists {
+		var roles []RoleRefModel
+		for _, role := range whitelist.Roles {
+			roles = append(roles, RoleRefModel{
+				ID:   types.StringValue(role.ID),
+				Name: types.StringValue(role.Name),
+			})
+		}
+		whitelists = append(whitelists, WhitelistGrantDataSourceModel{
+			Whitelist: newWhitelistModel(whitelist.Whitelist),
+			Roles:     roles,
+		})
+	}
+
+	return &CommandRestrictionsDataSourceModel{
+		RShellVariant:    types.StringValue(restrictions.RShellVariant),
+		Banner:           types.StringValue(restrictions.Banner),
+		Enabled:          types.BoolValue(restrictions.Enabled),
+		AllowNoMatch:     types.BoolValue(restrictions.AllowNoMatch),
+		AuditMatch:       types.BoolValue(restrictions.AuditMatch),
+		AuditNoMatch:     types.BoolValue(restrictions.AuditNoMatch),
+		DefaultWhitelist: newWhitelistModel(restrictions.DefaultWhitelist),
+		Whitelists:       whitelists,
+	}
+}
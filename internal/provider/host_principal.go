@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+
+	"github.com/SSHcom/privx-sdk-go/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+)
+
+// hostPrincipalSource is the source of the principals managed by
+// privx_host_principal resources. privx_host resources leave them alone.
+const hostPrincipalSource = hoststore.Source("terraform-host-principal")
+
+// hostUpdateAttempts is how many times a principal update is tried before
+// giving up on a host other clients keep updating.
+const hostUpdateAttempts = 5
+
+// hostsMu serializes the host updates of the provider, as the principals of
+// a host are updated through a read-modify-write of the whole host.
+var hostsMu sync.Mutex
+
+// rawHost is a host as returned by the host store. Writing it back rather
+// than a hoststore.Host keeps the attributes the SDK does not know about.
+type rawHost map[string]json.RawMessage
+
+// rawPrincipal is a principal of a rawHost.
+type rawPrincipal map[string]json.RawMessage
+
+// hostPrincipalFields are the attributes of a principal that
+// privx_host_principal resources manage.
+type hostPrincipalFields struct {
+	ID             string              `json:"principal"`
+	Roles          []rolestore.RoleRef `json:"roles"`
+	Source         hoststore.Source    `json:"source"`
+	UseUserAccount bool                `json:"use_user_account"`
+	Passphrase     string              `json:"passphrase"`
+}
+
+// The host store calls below go through the connector directly, as
+// hoststore.Host drops the attributes the SDK does not know about.
+
+func getRawHost(connector restapi.Connector, hostID string) (rawHost, error) {
+	host := rawHost{}
+
+	_, err := connector.
+		URL("/host-store/api/v1/hosts/%s", url.PathEscape(hostID)).
+		Get(&host)
+
+	return host, err
+}
+
+func updateRawHost(connector restapi.Connector, hostID string, host rawHost) error {
+	_, err := connector.
+		URL("/host-store/api/v1/hosts/%s", url.PathEscape(hostID)).
+		Put(host)
+
+	return err
+}
+
+// fingerprint returns a canonical encoding of the host, telling whether it
+// changed between two reads.
+func (h rawHost) fingerprint() (string, error) {
+	var host interface{}
+	b, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(b, &host); err != nil {
+		return "", err
+	}
+	b, err = json.Marshal(host)
+	return string(b), err
+}
+
+func (h rawHost) principals() ([]rawPrincipal, error) {
+	var principals []rawPrincipal
+	if raw, ok := h["principals"]; ok {
+		if err := json.Unmarshal(raw, &principals); err != nil {
+			return nil, fmt.Errorf("unable to decode host principals: %w", err)
+		}
+	}
+	return principals, nil
+}
+
+// principal returns the principal with the given account name, nil when the
+// host has none.
+func (h rawHost) principal(id string) (*hoststore.Principal, error) {
+	principals, err := h.principals()
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range principals {
+		if raw.id() != id {
+			continue
+		}
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		var principal hostPrincipal
+		if err := json.Unmarshal(b, &principal); err != nil {
+			return nil, fmt.Errorf("unable to decode host principal %s: %w", id, err)
+		}
+		return &principal.Principal, nil
+	}
+	return nil, nil
+}
+
+// setPrincipal replaces the principal with the given account name, keeping
+// the attributes privx_host_principal resources do not manage. A nil
+// principal removes it.
+func (h rawHost) setPrincipal(id string, principal *hostPrincipalFields) error {
+	principals, err := h.principals()
+	if err != nil {
+		return err
+	}
+
+	i := slices.IndexFunc(principals, func(raw rawPrincipal) bool { return raw.id() == id })
+	switch {
+	case principal == nil && i < 0:
+		return nil
+	case principal == nil:
+		principals = slices.Delete(principals, i, i+1)
+	default:
+		raw := rawPrincipal{}
+		if i >= 0 {
+			raw = principals[i]
+		}
+
+		b, err := json.Marshal(principal)
+		if err != nil {
+			return err
+		}
+		var fields rawPrincipal
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return err
+		}
+		for k, v := range fields {
+			raw[k] = v
+		}
+
+		if i < 0 {
+			principals = append(principals, raw)
+		}
+	}
+
+	if principals == nil {
+		principals = []rawPrincipal{}
+	}
+	h["principals"], err = json.Marshal(principals)
+	return err
+}
+
+func (p rawPrincipal) id() string {
+	var id string
+	_ = json.Unmarshal(p["principal"], &id)
+	return id
+}
+
+// matches tells whether the principal of the host has the attributes
+// written. The passphrase is left out, PrivX does not always return it.
+func (f *hostPrincipalFields) matches(principal *hoststore.Principal) bool {
+	if f == nil || principal == nil {
+		return f == nil && principal == nil
+	}
+	if f.UseUserAccount != principal.UseUserAccount || len(f.Roles) != len(principal.Roles) {
+		return false
+	}
+	for _, role := range f.Roles {
+		if !slices.ContainsFunc(principal.Roles, func(r rolestore.RoleRef) bool { return r.ID == role.ID }) {
+			return false
+		}
+	}
+	return true
+}
+
+// updateHostPrincipal sets the principal of the host to what update returns
+// given the current principal, nil meaning none. PrivX has no conditional
+// updates: the host is read again right before it is written back, and after,
+// and the update is tried again when another client updated the host in
+// between.
+func updateHostPrincipal(connector restapi.Connector, hostID, id string, update func(current *hoststore.Principal) (*hostPrincipalFields, error)) error {
+	hostsMu.Lock()
+	defer hostsMu.Unlock()
+
+	for attempt := 0; attempt < hostUpdateAttempts; attempt++ {
+		host, err := getRawHost(connector, hostID)
+		if err != nil {
+			return err
+		}
+		before, err := host.fingerprint()
+		if err != nil {
+			return err
+		}
+
+		current, err := host.principal(id)
+		if err != nil {
+			return err
+		}
+		principal, err := update(current)
+		if err != nil {
+			return err
+		}
+		if err := host.setPrincipal(id, principal); err != nil {
+			return err
+		}
+
+		latest, err := getRawHost(connector, hostID)
+		if err != nil {
+			return err
+		}
+		if after, err := latest.fingerprint(); err != nil {
+			return err
+		} else if after != before {
+			continue
+		}
+
+		if err := updateRawHost(connector, hostID, host); err != nil {
+			return err
+		}
+
+		written, err := getRawHost(connector, hostID)
+		if err != nil {
+			return err
+		}
+		current, err = written.principal(id)
+		if err != nil {
+			return err
+		}
+		if principal.matches(current) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host %s kept being updated concurrently, gave up updating principal %s after %d attempts", hostID, id, hostUpdateAttempts)
+}
@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-privx/internal/client"
+
+	"github.com/SSHcom/privx-sdk-go/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HostPrincipalResource{}
+var _ resource.ResourceWithImportState = &HostPrincipalResource{}
+
+func NewHostPrincipalResource() resource.Resource {
+	return &HostPrincipalResource{}
+}
+
+// HostPrincipalResource defines the resource implementation.
+type HostPrincipalResource struct {
+	endpoints
+	connector restapi.Connector
+}
+
+// HostPrincipalResourceModel describes the resource data model.
+type HostPrincipalResourceModel struct {
+	ID             types.String           `tfsdk:"id"`
+	HostID         types.String           `tfsdk:"host_id"`
+	Principal      types.String           `tfsdk:"principal"`
+	Passphrase     types.String           `tfsdk:"passphrase"`
+	UseUserAccount types.Bool             `tfsdk:"use_user_account"`
+	Roles          []RoleRefResourceModel `tfsdk:"roles"`
+	Source         types.String           `tfsdk:"source"`
+	Endpoint       types.String           `tfsdk:"endpoint"`
+}
+
+func (r *HostPrincipalResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_principal"
+}
+
+func (r *HostPrincipalResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Host principal resource. Adds a principal to an existing host, leaving the other principals of the host untouched. " +
+			"The principal is given the `terraform-host-principal` source, so that the `principals` of the `privx_host` resource of the host leave it out. " +
+			"Imported principals keep their source until the next apply, which gives them that source too. " +
+			"Apply it before removing them from the `principals` of the `privx_host` resource, which would otherwise delete them.\n\n" +
+			"PrivX updates hosts as a whole: the provider serializes its own updates of a host, and checks the host did not change right before writing it back. " +
+			"Terraform runs updating the same host concurrently can still overwrite each other's principals in between.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Host principal ID, as `<host_id>/<principal>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the host",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "The account name",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"use_user_account": schema.BoolAttribute{
+				MarkdownDescription: "Use user account as host principal name",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"passphrase": schema.StringAttribute{
+				MarkdownDescription: "The account static passphrase",
+				Optional:            true,
+				Computed:            true,
+				Sensitive:           true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Source of the principal in PrivX, `terraform-host-principal` once the resource manages it",
+				Computed:            true,
+				Default:             stringdefault.StaticString(string(hostPrincipalSource)),
+			},
+			"roles": schema.SetNestedAttribute{
+				MarkdownDescription: "An array of roles entitled to access this principal on the host",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Role UUID",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *HostPrincipalResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *HostPrincipalResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating hoststore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	r.connector = connector
+	return diags
+}
+
+func (data *HostPrincipalResourceModel) principal() *hostPrincipalFields {
+	roles := []rolestore.RoleRef{}
+	for _, role := range data.Roles {
+		roles = append(roles, rolestore.RoleRef{ID: role.ID.ValueString()})
+	}
+
+	return &hostPrincipalFields{
+		ID:             data.Principal.ValueString(),
+		Roles:          roles,
+		Source:         hostPrincipalSource,
+		UseUserAccount: data.UseUserAccount.ValueBool(),
+		Passphrase:     data.Passphrase.ValueString(),
+	}
+}
+
+func (r *HostPrincipalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *HostPrincipalResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostID, id := data.HostID.ValueString(), data.Principal.ValueString()
+	err := updateHostPrincipal(r.connector, hostID, id, func(current *hoststore.Principal) (*hostPrincipalFields, error) {
+		if current != nil {
+			return nil, fmt.Errorf("host %s already has principal %s, import it instead", hostID, id)
+		}
+		return data.principal(), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add host principal, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(hostID + "/" + id)
+
+	tflog.Debug(ctx, "created host principal resource", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostPrincipalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *HostPrincipalResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported resources only know their ID
+	hostID, id, ok := strings.Cut(data.ID.ValueString(), "/")
+	if !ok || hostID == "" || id == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid Host Principal ID",
+			fmt.Sprintf("Expected an ID of the form <host_id>/<principal>, got: %s", data.ID.ValueString()))
+		return
+	}
+	data.HostID = types.StringValue(hostID)
+	data.Principal = types.StringValue(id)
+	if data.Passphrase.IsNull() {
+		data.Passphrase = types.StringValue("")
+	}
+
+	host, err := getHost(r.connector, hostID)
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "host not found in PrivX, removing its principal from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read host, got error: %s", err))
+		return
+	}
+
+	var principal *hoststore.Principal
+	for i := range host.Principals {
+		if host.Principals[i].ID == id {
+			principal = &host.Principals[i].Principal
+		}
+	}
+	if principal == nil {
+		tflog.Warn(ctx, "host principal not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var roles []RoleRefResourceModel
+	for _, role := range principal.Roles {
+		roles = append(roles, RoleRefResourceModel{
+			ID: types.StringValue(role.ID),
+		})
+	}
+	data.Roles = roles
+	data.UseUserAccount = types.BoolValue(principal.UseUserAccount)
+	data.Source = types.StringValue(string(principal.Source))
+
+	tflog.Debug(ctx, "Storing host principal into the state", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostPrincipalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *HostPrincipalResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateHostPrincipal(r.connector, data.HostID.ValueString(), data.Principal.ValueString(), func(*hoststore.Principal) (*hostPrincipalFields, error) {
+		return data.principal(), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update host principal, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostPrincipalResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *HostPrincipalResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateHostPrincipal(r.connector, data.HostID.ValueString(), data.Principal.ValueString(), func(*hoststore.Principal) (*hostPrincipalFields, error) {
+		return nil, nil
+	})
+	if client.IsNotFound(err) {
+		// The principal went away along with the host
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove host principal, got error: %s", err))
+		return
+	}
+}
+
+func (r *HostPrincipalResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.importState(ctx, path.Root("id"), req, resp)
+}
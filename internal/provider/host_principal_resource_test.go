@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccCheckHostPrincipals verifies the principals of the host in PrivX.
+func testAccCheckHostPrincipals(t *testing.T, resourceName string, principals ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", resourceName)
+		}
+
+		host, err := getHost(testAccConnector(t), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		var got []string
+		for _, principal := range host.Principals {
+			got = append(got, principal.ID)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, principals) {
+			return fmt.Errorf("expected host principals %v, got %v", principals, got)
+		}
+		return nil
+	}
+}
+
+func TestAccHostPrincipalResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHostPrincipalResourceConfig(name, "10.0.0.10", "root"),
+				ExpectError: regexp.MustCompile("already has principal root"),
+			},
+			// Create and Read testing, the principals added concurrently are
+			// all kept
+			{
+				Config: testAccHostPrincipalResourceConfig(name, "10.0.0.10", "app"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "principals.#", "1"),
+					resource.TestCheckResourceAttr("privx_host_principal.test", "principal", "app"),
+					resource.TestCheckResourceAttr("privx_host_principal.test", "roles.#", "1"),
+					resource.TestCheckResourceAttrPair("privx_host_principal.test", "roles.0.id", "privx_role.test", "id"),
+					resource.TestCheckResourceAttr("privx_host_principal.other", "use_user_account", "true"),
+					testAccCheckHostPrincipals(t, "privx_host.test", "app", "other", "root"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_host_principal.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, updating the host keeps the principals
+			{
+				Config: testAccHostPrincipalResourceConfig(name, "10.0.0.11", "deploy"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host_principal.test", "principal", "deploy"),
+					testAccCheckHostPrincipals(t, "privx_host.test", "deploy", "other", "root"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckHostPrincipalSource verifies the source of a principal of the
+// host in PrivX.
+func testAccCheckHostPrincipalSource(t *testing.T, hostID, id string, taken bool) error {
+	host, err := getHost(testAccConnector(t), hostID)
+	if err != nil {
+		return err
+	}
+	for _, principal := range host.Principals {
+		if principal.ID == id && (principal.Source == hostPrincipalSource) != taken {
+			return fmt.Errorf("unexpected source %q of principal %s", principal.Source, id)
+		}
+	}
+	return nil
+}
+
+func TestAccHostPrincipalResourceImport(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfig(name, "10.0.0.10", "root"),
+			},
+			// Importing a principal of the host reports its source, leaving
+			// it untouched
+			{
+				Config:             testAccHostPrincipalResourceImportConfig(name, "root"),
+				ResourceName:       "privx_host_principal.root",
+				ImportState:        true,
+				ImportStatePersist: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["privx_host.test"].Primary.ID + "/root", nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					for _, state := range states {
+						if !strings.HasSuffix(state.ID, "/root") {
+							continue
+						}
+						if source := state.Attributes["source"]; source == string(hostPrincipalSource) {
+							return fmt.Errorf("expected the source of the host, got %q", source)
+						}
+						return testAccCheckHostPrincipalSource(t, state.Attributes["host_id"], "root", false)
+					}
+					return fmt.Errorf("imported principal root not found")
+				},
+			},
+			// Applying takes the principal over, the host then no longer
+			// manages it and plans to add it back
+			{
+				Config: testAccHostPrincipalResourceImportConfig(name, "root"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host_principal.root", "source", string(hostPrincipalSource)),
+					func(s *terraform.State) error {
+						return testAccCheckHostPrincipalSource(t, s.RootModule().Resources["privx_host.test"].Primary.ID, "root", true)
+					},
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			// Leaving the principal out of the principals of the host keeps it
+			{
+				Config: testAccHostPrincipalResourceImportConfig(name, "admin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "principals.#", "1"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.principal", "admin"),
+					testAccCheckHostPrincipals(t, "privx_host.test", "admin", "root"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccHostPrincipalResourceConfig(name, address, principal string) string {
+	return testAccHostResourceConfig(name, address, "root") + fmt.Sprintf(`
+resource "privx_host_principal" "test" {
+  host_id   = privx_host.test.id
+  principal = %[1]q
+
+  roles = [
+    {
+      id = privx_role.test.id
+    },
+  ]
+}
+
+resource "privx_host_principal" "other" {
+  host_id          = privx_host.test.id
+  principal        = "other"
+  use_user_account = true
+}
+`, principal)
+}
+
+func testAccHostPrincipalResourceImportConfig(name, principal string) string {
+	return testAccHostResourceConfig(name, "10.0.0.10", principal) + `
+resource "privx_host_principal" "root" {
+  host_id   = privx_host.test.id
+  principal = "root"
+
+  roles = [
+    {
+      id = privx_role.test.id
+    },
+  ]
+}
+`
+}
@@ -2,18 +2,28 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
+	"github.com/SSHcom/privx-sdk-go/api/authorizer"
 	"github.com/SSHcom/privx-sdk-go/api/hoststore"
 	"github.com/SSHcom/privx-sdk-go/api/rolestore"
 	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -26,6 +36,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HostResource{}
 var _ resource.ResourceWithImportState = &HostResource{}
+var _ resource.ResourceWithValidateConfig = &HostResource{}
 
 type Address types.String
 
@@ -36,7 +47,10 @@ func NewHostResource() resource.Resource {
 type (
 	// HostResource defines the resource implementation.
 	HostResource struct {
-		client *hoststore.HostStore
+		endpoints
+		client     *hoststore.HostStore
+		authorizer *authorizer.Client
+		connector  restapi.Connector
 	}
 
 	ServiceModel struct {
@@ -44,12 +58,9 @@ type (
 		Address types.String `tfsdk:"address"`
 		Port    types.Int64  `tfsdk:"port"`
 
-		/* FIXME: Not implemented in privx-sdk-go v1.29.0
-		UseForPasswordRotation types.Bool   `tfsdk:"use_for_password_rotation"`
-		*/
+		UseForPasswordRotation types.Bool `tfsdk:"use_for_password_rotation"`
 	}
 
-	/* FIXME: Not implemented in privx-sdk-go v1.29.0
 	ApplicationModel struct {
 		Name types.String `tfsdk:"name"`
 
@@ -57,7 +68,6 @@ type (
 		Arguments        types.String `tfsdk:"arguments"`
 		WorkingDirectory types.String `tfsdk:"working_directory"`
 	}
-	*/
 
 	SSHServiceModel struct {
 		Shell        types.Bool `tfsdk:"shell"`
@@ -75,9 +85,9 @@ type (
 	}
 
 	ServiceOptionsModel struct {
-		SSH SSHServiceModel `tfsdk:"ssh"`
-		RDP RDPServiceModel `tfsdk:"rdp"`
-		Web RDPServiceModel `tfsdk:"Web"` //RDP and Web models are the same
+		SSH *SSHServiceModel `tfsdk:"ssh"`
+		RDP *RDPServiceModel `tfsdk:"rdp"`
+		Web *RDPServiceModel `tfsdk:"web"` //RDP and Web models are the same
 	}
 
 	WhitelistModel struct {
@@ -86,30 +96,36 @@ type (
 		Deleted types.Bool   `tfsdk:"deleted"`
 	}
 
-	/* FIXME: Not implemented in privx-sdk-go v1.29.0
+	WhitelistRefResourceModel struct {
+		ID types.String `tfsdk:"id"`
+	}
+
+	// Whitelist granted to the members of roles.
+	WhitelistGrantModel struct {
+		Whitelist WhitelistRefResourceModel `tfsdk:"whitelist"`
+		Roles     []RoleRefResourceModel    `tfsdk:"roles"`
+	}
+
 	CommandRestrictionsModel struct {
-		RShellVariant    types.String     `tfsdk:"rshell_variant"`
-		Banner           types.String     `tfsdk:"banner"`
-		Enabled          types.Bool       `tfsdk:"enabled"`
-		AllowNoMatch     types.Bool       `tfsdk:"allow_no_match"`
-		AuditMatch       types.Bool       `tfsdk:"audit_match"`
-		AuditNoMatch     types.Bool       `tfsdk:"audit_no_match"`
-		DefalutWhitelist WhitelistModel   `tfsdk:"default_whitelist"`
-		Whitelists       []WhitelistModel `tfsdk:"whitelists"`
-	}
-	*/
-
-	/* FIXME: Not implemented in privx-sdk-go v1.29.0
+		RShellVariant    types.String               `tfsdk:"rshell_variant"`
+		Banner           types.String               `tfsdk:"banner"`
+		Enabled          types.Bool                 `tfsdk:"enabled"`
+		AllowNoMatch     types.Bool                 `tfsdk:"allow_no_match"`
+		AuditMatch       types.Bool                 `tfsdk:"audit_match"`
+		AuditNoMatch     types.Bool                 `tfsdk:"audit_no_match"`
+		DefaultWhitelist *WhitelistRefResourceModel `tfsdk:"default_whitelist"`
+		Whitelists       []WhitelistGrantModel      `tfsdk:"whitelists"`
+	}
+
 	PasswordRotationModel struct {
 		OperatingSystem  types.String `tfsdk:"operating_system"`
 		WINRMAddress     types.String `tfsdk:"winrm_address"`
-		WINRMPort        types.String `tfsdk:"winrm_port"`
+		WINRMPort        types.Int64  `tfsdk:"winrm_port"`
 		Protocol         types.String `tfsdk:"protocol"`
 		PasswordPolicyID types.String `tfsdk:"password_policy_id"`
 		ScriptTemplateID types.String `tfsdk:"script_template_id"`
 		UseMainAccount   types.Bool   `tfsdk:"use_main_account"`
 	}
-	*/
 
 	RoleRefResourceModel struct {
 		ID types.String `tfsdk:"id"`
@@ -122,13 +138,11 @@ type (
 		UseUserAccount types.Bool             `tfsdk:"use_user_account"`
 		Roles          []RoleRefResourceModel `tfsdk:"roles"`
 
-		/* FIXME: Not implemented in privx-sdk-go v1.29.0
-		Applications   []ApplicationModel     `tfsdk:"applications"`
-		Rotate                 types.Bool               `tfsdk:"rotate"`
-		UseForPasswordRotation types.Bool               `tfsdk:"use_for_password_rotation"`
-		ServiceOptions         ServiceOptionsModel      `tfsdk:"service_options"`
-		CommandRestrictions    CommandRestrictionsModel `tfsdk:"command_restrictions"`
-		*/
+		Rotate                 types.Bool                `tfsdk:"rotate"`
+		UseForPasswordRotation types.Bool                `tfsdk:"use_for_password_rotation"`
+		ServiceOptions         *ServiceOptionsModel      `tfsdk:"service_options"`
+		CommandRestrictions    *CommandRestrictionsModel `tfsdk:"command_restrictions"`
+		Applications           []ApplicationModel        `tfsdk:"applications"`
 	}
 
 	SSHPublicKeyModel struct {
@@ -161,17 +175,17 @@ type (
 		Principals          []PrincipalModel    `tfsdk:"principals"`
 		PublicKeys          []SSHPublicKeyModel `tfsdk:"ssh_host_public_keys"`
 
-		/* FIXME: Not implemented in privx-sdk-go v1.29.0
-		CertificateTemplate     types.String          `tfsdk:"certificate_template"`
-		HostCertificateRaw      types.String          `tfsdk:"host_certificate_raw"`
-		PasswordRotationEnabled types.Bool            `tfsdk:"password_rotation_enabled"`
-		PasswordRotation        PasswordRotationModel `tfsdk:"password_rotation"`
-		*/
+		PasswordRotationEnabled types.Bool             `tfsdk:"password_rotation_enabled"`
+		PasswordRotation        *PasswordRotationModel `tfsdk:"password_rotation"`
+
+		CertificateTemplate types.String `tfsdk:"certificate_template"`
+		HostCertificateRaw  types.String `tfsdk:"host_certificate_raw"`
 
 		/* Set by privx, not needed in resource
 		SourceID            types.String        `tfsdk:"source_id"`
 		Deployable          types.Bool          `tfsdk:"deployable"`
 		*/
+		Endpoint types.String `tfsdk:"endpoint"`
 	}
 )
 
@@ -185,6 +199,7 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		MarkdownDescription: "Host resource",
 
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Host ID",
 				Computed:            true,
@@ -199,10 +214,14 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"external_id": schema.StringAttribute{
 				MarkdownDescription: "The equipment ID from the originating equipment store",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
 			},
 			"instance_id": schema.StringAttribute{
 				MarkdownDescription: "The instance ID from the originating cloud service (searchable by keyword)",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
 			},
 			"common_name": schema.StringAttribute{
 				MarkdownDescription: "X.500 Common name (searchable by keyword)",
@@ -271,12 +290,10 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"host_certificate_raw": schema.StringAttribute{
-				MarkdownDescription: "Host certificate, used to verify that the target host is the correct one.",
+				MarkdownDescription: "Host certificate, used to verify that the target host is the correct one. PEM encoded",
 				Optional:            true,
 			},
-			*/
 			"tofu": schema.BoolAttribute{
 				MarkdownDescription: "Whether the host key should be accepted and stored on first connection",
 				Optional:            true,
@@ -325,12 +342,14 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
 			},
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"certificate_template": schema.StringAttribute{
-				MarkdownDescription: "Name of the certificate template used for certificate authentication for this host",
-				Optional:            true,
+				MarkdownDescription: "Name of the certificate template used for certificate authentication for this host. " +
+					"Must name one of the certificate templates of PrivX",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
-			*/
 			"ssh_host_public_keys": schema.SetNestedAttribute{
 				MarkdownDescription: "Host public keys, used to verify the identity of the accessed host",
 				Optional:            true,
@@ -363,37 +382,46 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 							MarkdownDescription: "Service port",
 							Optional:            true,
 						},
-						/*
-							"use_for_password_rotation": schema.BoolAttribute{
-								MarkdownDescription: "if service SSH, informs whether this service is used to rotate password",
-								Optional:            true,
-							},
-						*/
+						"use_for_password_rotation": schema.BoolAttribute{
+							MarkdownDescription: "if service SSH, informs whether this service is used to rotate password",
+							Optional:            true,
+						},
 					},
 				},
 			},
+			// The framework tells whether an attribute nested in a set is
+			// configured by looking up the planned element in the configuration,
+			// which misses as soon as a computed attribute of the element is
+			// unset: the defaults of the element then override its configured
+			// values. The attributes added to the services and principals sets
+			// have no defaults for that reason. use_user_account and passphrase
+			// keep theirs, so that existing states plan no change, and
+			// principalDefaults restores their configured values.
 			"principals": schema.SetNestedAttribute{
-				MarkdownDescription: "What principals (target server user names/ accounts) the host has",
+				MarkdownDescription: "What principals (target server user names/ accounts) the host has. The principals managed by `privx_host_principal` resources are left out",
 				Optional:            true,
+				PlanModifiers: []planmodifier.Set{
+					principalDefaults{},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"principal": schema.StringAttribute{
 							MarkdownDescription: "The account name",
 							Required:            true,
 						},
-						/* FIXME: Not implemented in privx-sdk-go v1.29.0
 						"rotate": schema.BoolAttribute{
 							MarkdownDescription: "Rotate password of this account",
 							Optional:            true,
 						},
 						"use_for_password_rotation": schema.BoolAttribute{
-							MarkdownDescription: "marks account to be used as the account through which password rotation takes place, when flag use_main_account set in rotation_metadata",
+							MarkdownDescription: "marks account to be used as the account through which password rotation takes place, when flag use_main_account set in password_rotation",
 							Optional:            true,
 						},
-						*/
 						"use_user_account": schema.BoolAttribute{
 							MarkdownDescription: "Use user account as host principal name",
 							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
 						},
 						"passphrase": schema.StringAttribute{
 							MarkdownDescription: "The account static passphrase or the initial rotating password value. If rotate selected, active in create, disabled/hidden in edit",
@@ -414,31 +442,32 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 								},
 							},
 						},
-						/* FIXME: Not implemented in privx-sdk-go v1.29.0
 						"applications": schema.SetNestedAttribute{
 							MarkdownDescription: "An array of application the principal may launch on the target host",
 							Optional:            true,
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"name": schema.StringAttribute{
-										Optional: true,
+										MarkdownDescription: "Application name, unique among the applications of the principal",
+										Required:            true,
 									},
 									"application": schema.StringAttribute{
-										Optional: true,
+										MarkdownDescription: "Path of the application executable on the host",
+										Required:            true,
 									},
 									"arguments": schema.StringAttribute{
-										Optional: true,
+										MarkdownDescription: "Command line arguments of the application",
+										Optional:            true,
 									},
 									"working_directory": schema.StringAttribute{
-										Optional: true,
+										MarkdownDescription: "Working directory of the application",
+										Optional:            true,
 									},
 								},
 							},
 						},
-						*/
-						/* FIXME: Not implemented in privx-sdk-go v1.29.0
 						"service_options": schema.SingleNestedAttribute{
-							MarkdownDescription: "Object for service options",
+							MarkdownDescription: "Service features allowed to the principal. The features left unset stay enabled, as PrivX defaults them",
 							Optional:            true,
 							Attributes: map[string]schema.Attribute{
 								"ssh": schema.SingleNestedAttribute{
@@ -454,25 +483,25 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 											Optional:            true,
 										},
 										"exec": schema.BoolAttribute{
-											MarkdownDescription: "exec_channel",
+											MarkdownDescription: "Exec channel",
 											Optional:            true,
 										},
 										"tunnels": schema.BoolAttribute{
-											MarkdownDescription: "tunnels",
+											MarkdownDescription: "Tunnels",
 											Optional:            true,
 										},
 										"x11": schema.BoolAttribute{
-											MarkdownDescription: "x11",
+											MarkdownDescription: "X11 forwarding",
 											Optional:            true,
 										},
 										"other": schema.BoolAttribute{
-											MarkdownDescription: "other options",
+											MarkdownDescription: "Other channels",
 											Optional:            true,
 										},
 									},
 								},
 								"rdp": schema.SingleNestedAttribute{
-									MarkdownDescription: "SSH service options",
+									MarkdownDescription: "RDP service options",
 									Optional:            true,
 									Attributes: map[string]schema.Attribute{
 										"file_transfer": schema.BoolAttribute{
@@ -480,21 +509,17 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 											Optional:            true,
 										},
 										"audio": schema.BoolAttribute{
-											MarkdownDescription: "audio",
+											MarkdownDescription: "Audio",
 											Optional:            true,
 										},
 										"clipboard": schema.BoolAttribute{
-											MarkdownDescription: "clipboard",
-											Optional:            true,
-										},
-										"web": schema.BoolAttribute{
-											MarkdownDescription: "WEB service options",
+											MarkdownDescription: "Clipboard",
 											Optional:            true,
 										},
 									},
 								},
 								"web": schema.SingleNestedAttribute{
-									MarkdownDescription: "SSH service options",
+									MarkdownDescription: "Web service options",
 									Optional:            true,
 									Attributes: map[string]schema.Attribute{
 										"file_transfer": schema.BoolAttribute{
@@ -502,11 +527,11 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 											Optional:            true,
 										},
 										"audio": schema.BoolAttribute{
-											MarkdownDescription: "audio",
+											MarkdownDescription: "Audio",
 											Optional:            true,
 										},
 										"clipboard": schema.BoolAttribute{
-											MarkdownDescription: "clipboard",
+											MarkdownDescription: "Clipboard",
 											Optional:            true,
 										},
 									},
@@ -514,7 +539,7 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 							},
 						},
 						"command_restrictions": schema.SingleNestedAttribute{
-							MarkdownDescription: "Host services",
+							MarkdownDescription: "Restricted shell settings, limiting the commands run as the principal to the patterns of command whitelists",
 							Optional:            true,
 							Attributes: map[string]schema.Attribute{
 								"enabled": schema.BoolAttribute{
@@ -529,14 +554,6 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 											MarkdownDescription: "Whitelist ID",
 											Required:            true,
 										},
-										"name": schema.StringAttribute{
-											MarkdownDescription: "Whitelist name",
-											Optional:            true,
-										},
-										"deleted": schema.BoolAttribute{
-											MarkdownDescription: "Has whitelist been deleted, ignored in requests",
-											Optional:            true,
-										},
 									},
 								},
 								"rshell_variant": schema.StringAttribute{
@@ -563,31 +580,23 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 									Optional:            true,
 								},
 								"whitelists": schema.SetNestedAttribute{
-									Optional: true,
+									MarkdownDescription: "Whitelists granted to the members of roles, on top of the default whitelist",
+									Optional:            true,
 									NestedObject: schema.NestedAttributeObject{
 										Attributes: map[string]schema.Attribute{
 											"whitelist": schema.SingleNestedAttribute{
-												Optional: true,
+												MarkdownDescription: "Whitelist handle",
+												Required:            true,
 												Attributes: map[string]schema.Attribute{
 													"id": schema.StringAttribute{
 														MarkdownDescription: "Whitelist ID",
 														Required:            true,
 													},
-													"name": schema.StringAttribute{
-														MarkdownDescription: "Whitelist name",
-														Optional:            true,
-													},
-
-													"deleted": schema.BoolAttribute{
-														MarkdownDescription: "Has whitelist been deleted, ignored in requests",
-														Optional:            true,
-													},
 												},
 											},
 											"roles": schema.SetNestedAttribute{
 												MarkdownDescription: "List of roles granting access to the whitelist",
 												Optional:            true,
-
 												NestedObject: schema.NestedAttributeObject{
 													Attributes: map[string]schema.Attribute{
 														"id": schema.StringAttribute{
@@ -602,17 +611,15 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 								},
 							},
 						},
-						*/
 					},
 				},
 			},
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"password_rotation_enabled": schema.BoolAttribute{
 				MarkdownDescription: "set, if there are accounts, in which passwords need to be rotated",
 				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
-			*/
-			/* FIXME: Not implemented in privx-sdk-go v1.29.0
 			"password_rotation": schema.SingleNestedAttribute{
 				MarkdownDescription: "password rotation settings for host",
 				Optional:            true,
@@ -631,10 +638,17 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					"winrm_address": schema.StringAttribute{
 						MarkdownDescription: "IPv4 address or FQDN to use for winrm connection",
 						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(""),
 					},
 					"winrm_port": schema.Int64Attribute{
 						MarkdownDescription: "port to use for password rotation with winrm, zero for winrm default",
 						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(0),
+						Validators: []validator.Int64{
+							int64validator.Between(0, 65535),
+						},
 					},
 					"protocol": schema.StringAttribute{
 						MarkdownDescription: "protocol (SSH | WINRM)",
@@ -653,7 +667,6 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					},
 				},
 			},
-			*/
 		},
 	}
 }
@@ -664,66 +677,546 @@ func (r *HostResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *HostResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating hoststore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = hoststore.New(*connector)
+	r.client = hoststore.New(connector)
+	r.authorizer = authorizer.New(connector)
+	r.connector = connector
+	return diags
 }
 
-func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data HostResourceModel
-
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+func (r *HostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var enabled types.Bool
+	var rotation types.Object
+	var principals types.Set
+	var certificate types.String
 
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_rotation_enabled"), &enabled)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_rotation"), &rotation)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("principals"), &principals)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("host_certificate_raw"), &certificate)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, "Loaded host type data", map[string]interface{}{
-		"data": fmt.Sprintf("%+v", data),
-	})
+	if !certificate.IsNull() && !certificate.IsUnknown() {
+		if _, err := parseCertificates(certificate.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("host_certificate_raw"), "Invalid Host Certificate",
+				fmt.Sprintf("Unable to parse host_certificate_raw, got error: %s", err))
+		}
+	}
 
-	var scopePayload []string
-	resp.Diagnostics.Append(data.Scope.ElementsAs(ctx, &scopePayload, false)...)
-	if resp.Diagnostics.HasError() {
+	if enabled.ValueBool() && rotation.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("password_rotation"), "Missing Password Rotation Settings",
+			"password_rotation is required when password_rotation_enabled is true")
+	}
+
+	if !rotation.IsNull() && !rotation.IsUnknown() {
+		attributes := rotation.Attributes()
+		if protocol, ok := attributes["protocol"].(types.String); ok && protocol.ValueString() == "SSH" {
+			for _, name := range []string{"winrm_address", "winrm_port"} {
+				if value := attributes[name]; !value.IsNull() && !value.IsUnknown() {
+					resp.Diagnostics.AddAttributeError(path.Root("password_rotation").AtName(name), "Invalid Password Rotation Settings",
+						fmt.Sprintf("%s only applies to the WINRM protocol", name))
+				}
+			}
+		}
+	}
+
+	// Principals that are not known yet are validated once they are
+	if principals.IsNull() || principals.IsUnknown() {
 		return
 	}
+	rotationAccounts, known := 0, true
+	for _, element := range principals.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			known = false
+			continue
+		}
+		attributes := object.Attributes()
+
+		if applications, ok := attributes["applications"].(types.Set); ok && !applications.IsUnknown() {
+			names := map[string]bool{}
+			for _, application := range applications.Elements() {
+				object, ok := application.(types.Object)
+				if !ok || object.IsUnknown() {
+					continue
+				}
+				name, ok := object.Attributes()["name"].(types.String)
+				if !ok || name.IsNull() || name.IsUnknown() {
+					continue
+				}
+				if names[name.ValueString()] {
+					resp.Diagnostics.AddAttributeError(path.Root("principals").AtSetValue(element).AtName("applications"), "Duplicate Application Name",
+						fmt.Sprintf("The principal has several applications named %q", name.ValueString()))
+				}
+				names[name.ValueString()] = true
+			}
+		}
 
-	var tagsPayload []string
-	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tagsPayload, false)...)
-	if resp.Diagnostics.HasError() {
+		if restrictions, ok := attributes["command_restrictions"].(types.Object); ok && !restrictions.IsNull() && !restrictions.IsUnknown() {
+			restrictionAttributes := restrictions.Attributes()
+			if enabled, ok := restrictionAttributes["enabled"].(types.Bool); ok && enabled.ValueBool() {
+				for _, name := range []string{"default_whitelist", "rshell_variant"} {
+					if value := restrictionAttributes[name]; value == nil || value.IsNull() {
+						resp.Diagnostics.AddAttributeError(path.Root("principals").AtSetValue(element).AtName("command_restrictions").AtName(name), "Missing Command Restrictions Settings",
+							fmt.Sprintf("%s is required when command restrictions are enabled", name))
+					}
+				}
+			}
+		}
+
+		if rotate, ok := attributes["rotate"].(types.Bool); ok && rotate.ValueBool() && !enabled.IsUnknown() && !enabled.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("principals").AtSetValue(element).AtName("rotate"), "Password Rotation Disabled",
+				"Rotating the password of a principal requires password_rotation_enabled to be true")
+		}
+
+		switch account, ok := attributes["use_for_password_rotation"].(types.Bool); {
+		case !ok || account.IsUnknown():
+			known = false
+		case account.ValueBool():
+			rotationAccounts++
+		}
+	}
+
+	if rotation.IsNull() || rotation.IsUnknown() || !known {
 		return
 	}
+	if useMainAccount, ok := rotation.Attributes()["use_main_account"].(types.Bool); ok && useMainAccount.ValueBool() && rotationAccounts != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("principals"), "Invalid Password Rotation Account",
+			fmt.Sprintf("use_main_account requires exactly one principal with use_for_password_rotation, got %d", rotationAccounts))
+	}
+}
 
-	var addressesPayload []hoststore.Address
-	resp.Diagnostics.Append(data.Addresses.ElementsAs(ctx, &addressesPayload, false)...)
+// principalDefaults restores the configured use_user_account and passphrase
+// of the planned principals, which the defaults may have overridden, see the
+// principals attribute.
+type principalDefaults struct{}
+
+func (m principalDefaults) Description(ctx context.Context) string {
+	return "Keeps the configured passphrase and use_user_account of the principals."
+}
+
+func (m principalDefaults) MarkdownDescription(ctx context.Context) string {
+	return "Keeps the configured `passphrase` and `use_user_account` of the principals."
+}
+
+func (m principalDefaults) PlanModifySet(ctx context.Context, req planmodifier.SetRequest, resp *planmodifier.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || resp.PlanValue.IsNull() || resp.PlanValue.IsUnknown() {
+		return
+	}
+
+	configured := map[string]map[string]attr.Value{}
+	for _, element := range req.ConfigValue.Elements() {
+		object, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		attributes := object.Attributes()
+		if id, ok := attributes["principal"].(types.String); ok && !id.IsNull() && !id.IsUnknown() {
+			configured[id.ValueString()] = attributes
+		}
+	}
+
+	var elements []attr.Value
+	for _, element := range resp.PlanValue.Elements() {
+		object, ok := element.(types.Object)
+		if !ok {
+			return
+		}
+		attributes := object.Attributes()
+		id, _ := attributes["principal"].(types.String)
+		if config, ok := configured[id.ValueString()]; ok && !id.IsUnknown() {
+			for _, name := range []string{"passphrase", "use_user_account"} {
+				if value, ok := config[name]; ok && !value.IsNull() {
+					attributes[name] = value
+				}
+			}
+		}
+
+		object, diags := types.ObjectValue(object.AttributeTypes(ctx), attributes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elements = append(elements, object)
+	}
+
+	plan, diags := types.SetValue(resp.PlanValue.ElementType(ctx), elements)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.PlanValue = plan
+}
+
+// optionalBool returns the value of an optional flag without default, which
+// stays null while it is unset.
+func optionalBool(value bool, prior types.Bool) types.Bool {
+	if !value && prior.IsNull() {
+		return types.BoolNull()
+	}
+	return types.BoolValue(value)
+}
+
+// parseCertificates parses the PEM encoded certificates of raw, failing on
+// anything else.
+func parseCertificates(raw string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(raw)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("expected a CERTIFICATE PEM block, got %s", block.Type)
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fmt.Errorf("unexpected data after the PEM blocks")
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certificates, nil
+}
+
+// priorCertificates returns the PEM encoded certificates, keeping the prior
+// encoding when PrivX only reformatted it.
+func priorCertificates(raw string, prior types.String) types.String {
+	if prior.IsNull() {
+		return optionalString(raw)
+	}
+	certificates, err := parseCertificates(raw)
+	if err != nil {
+		return optionalString(raw)
+	}
+	priorCertificates, err := parseCertificates(prior.ValueString())
+	if err != nil || !slices.EqualFunc(certificates, priorCertificates, (*x509.Certificate).Equal) {
+		return optionalString(raw)
+	}
+	return prior
+}
+
+// checkCertificateTemplate reports a certificate template PrivX does not
+// have, which PrivX would otherwise only report when connecting to the host.
+func (r *HostResource) checkCertificateTemplate(name types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if name.IsNull() {
+		return diags
+	}
+
+	templates, err := r.authorizer.CertTemplates("")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list certificate templates, got error: %s", err))
+		return diags
+	}
+	var names []string
+	for _, template := range templates {
+		if template.Name == name.ValueString() {
+			return diags
+		}
+		names = append(names, template.Name)
+	}
+	diags.AddAttributeError(path.Root("certificate_template"), "Unknown Certificate Template",
+		fmt.Sprintf("No certificate template is named %q, expected one of: %s", name.ValueString(), strings.Join(names, ", ")))
+	return diags
+}
+
+// priorString returns the value of an optional string without default,
+// which stays null while it is unset.
+func priorString(value string, prior types.String) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// newApplicationModels returns the models of the applications of a
+// principal, keeping the unset attributes of the prior models null.
+func newApplicationModels(applications []hostApplication, prior []ApplicationModel) []ApplicationModel {
+	var models []ApplicationModel
+	for _, a := range applications {
+		priorApplication := ApplicationModel{
+			Arguments:        types.StringNull(),
+			WorkingDirectory: types.StringNull(),
+		}
+		for _, pa := range prior {
+			if pa.Name.ValueString() == a.Name {
+				priorApplication = pa
+			}
+		}
+		models = append(models, ApplicationModel{
+			Name:             types.StringValue(a.Name),
+			Application:      types.StringValue(a.Application),
+			Arguments:        priorString(a.Arguments, priorApplication.Arguments),
+			WorkingDirectory: priorString(a.WorkingDirectory, priorApplication.WorkingDirectory),
+		})
+	}
+	return models
+}
+
+// enabledByDefault returns the value of an optional flag PrivX enables by
+// default.
+func enabledByDefault(value types.Bool) bool {
+	return value.IsNull() || value.ValueBool()
+}
+
+// optionalEnabled returns the value of an optional flag PrivX enables by
+// default, which stays null while it is unset.
+func optionalEnabled(value bool, prior types.Bool) types.Bool {
+	if value && prior.IsNull() {
+		return types.BoolNull()
+	}
+	return types.BoolValue(value)
+}
+
+// payload returns the service options described by the model, the options
+// left unset being enabled.
+func (m *ServiceOptionsModel) payload() *serviceOptions {
+	if m == nil {
+		return nil
+	}
+
+	options := &serviceOptions{}
+	if ssh := m.SSH; ssh != nil {
+		options.SSH = &hoststore.SSHService{
+			Shell:        enabledByDefault(ssh.Shell),
+			FileTransfer: enabledByDefault(ssh.FileTransfer),
+			Exec:         enabledByDefault(ssh.Exec),
+			Tunnels:      enabledByDefault(ssh.Tunnels),
+			Xeleven:      enabledByDefault(ssh.X11),
+			Other:        enabledByDefault(ssh.Other),
+		}
+	}
+	if rdp := m.RDP; rdp != nil {
+		options.RDP = &hoststore.RDPService{
+			FileTransfer: enabledByDefault(rdp.FileTransfer),
+			Audio:        enabledByDefault(rdp.Audio),
+			Clipboard:    enabledByDefault(rdp.Clipboard),
+		}
+	}
+	if web := m.Web; web != nil {
+		options.Web = &hoststore.WebService{
+			FileTransfer: enabledByDefault(web.FileTransfer),
+			Audio:        enabledByDefault(web.Audio),
+			Clipboard:    enabledByDefault(web.Clipboard),
+		}
+	}
+	return options
+}
+
+// newServiceOptionsModel returns the model of the service options of a
+// principal, keeping the unset options of the prior model null.
+func newServiceOptionsModel(options *serviceOptions, prior *ServiceOptionsModel) *ServiceOptionsModel {
+	options = options.withDefaults()
+
+	model := &ServiceOptionsModel{}
+	if prior != nil {
+		model = prior
+	}
+	model = &ServiceOptionsModel{
+		SSH: newSSHServiceModel(*options.SSH, model.SSH),
+		RDP: newRDPServiceModel(*options.RDP, model.RDP),
+		Web: newRDPServiceModel(hoststore.RDPService(*options.Web), model.Web),
+	}
+	if prior == nil && model.SSH == nil && model.RDP == nil && model.Web == nil {
+		return nil
+	}
+	return model
+}
 
-	var servicesPayload []hoststore.Service
+func newSSHServiceModel(ssh hoststore.SSHService, prior *SSHServiceModel) *SSHServiceModel {
+	if prior == nil {
+		if ssh == defaultSSHService {
+			return nil
+		}
+		prior = &SSHServiceModel{
+			Shell:        types.BoolNull(),
+			FileTransfer: types.BoolNull(),
+			Exec:         types.BoolNull(),
+			Tunnels:      types.BoolNull(),
+			X11:          types.BoolNull(),
+			Other:        types.BoolNull(),
+		}
+	}
+
+	return &SSHServiceModel{
+		Shell:        optionalEnabled(ssh.Shell, prior.Shell),
+		FileTransfer: optionalEnabled(ssh.FileTransfer, prior.FileTransfer),
+		Exec:         optionalEnabled(ssh.Exec, prior.Exec),
+		Tunnels:      optionalEnabled(ssh.Tunnels, prior.Tunnels),
+		X11:          optionalEnabled(ssh.Xeleven, prior.X11),
+		Other:        optionalEnabled(ssh.Other, prior.Other),
+	}
+}
+
+func newRDPServiceModel(rdp hoststore.RDPService, prior *RDPServiceModel) *RDPServiceModel {
+	if prior == nil {
+		if rdp == defaultRDPService {
+			return nil
+		}
+		prior = &RDPServiceModel{
+			FileTransfer: types.BoolNull(),
+			Audio:        types.BoolNull(),
+			Clipboard:    types.BoolNull(),
+		}
+	}
+
+	return &RDPServiceModel{
+		FileTransfer: optionalEnabled(rdp.FileTransfer, prior.FileTransfer),
+		Audio:        optionalEnabled(rdp.Audio, prior.Audio),
+		Clipboard:    optionalEnabled(rdp.Clipboard, prior.Clipboard),
+	}
+}
+
+// payload returns the command restrictions described by the model.
+func (m *CommandRestrictionsModel) payload() *commandRestrictions {
+	if m == nil {
+		return nil
+	}
+
+	whitelists := []whitelistGrant{}
+	for _, whitelist := range m.Whitelists {
+		roles := []rolestore.RoleRef{}
+		for _, role := range whitelist.Roles {
+			roles = append(roles, rolestore.RoleRef{ID: role.ID.ValueString()})
+		}
+		whitelists = append(whitelists, whitelistGrant{
+			Whitelist: whitelistHandle{ID: whitelist.Whitelist.ID.ValueString()},
+			Roles:     roles,
+		})
+	}
+
+	restrictions := &commandRestrictions{
+		Enabled:       m.Enabled.ValueBool(),
+		Whitelists:    whitelists,
+		RShellVariant: m.RShellVariant.ValueString(),
+		Banner:        m.Banner.ValueString(),
+		AllowNoMatch:  m.AllowNoMatch.ValueBool(),
+		AuditMatch:    m.AuditMatch.ValueBool(),
+		AuditNoMatch:  m.AuditNoMatch.ValueBool(),
+	}
+	if m.DefaultWhitelist != nil {
+		restrictions.DefaultWhitelist.ID = m.DefaultWhitelist.ID.ValueString()
+	}
+	return restrictions
+}
+
+// newCommandRestrictionsModel returns the model of the command restrictions
+// of a principal, keeping the unset attributes of the prior model null.
+func newCommandRestrictionsModel(restrictions *commandRestrictions, prior *CommandRestrictionsModel) *CommandRestrictionsModel {
+	if restrictions.isEmpty() && prior == nil {
+		return nil
+	}
+	if prior == nil {
+		prior = &CommandRestrictionsModel{
+			RShellVariant: types.StringNull(),
+			Banner:        types.StringNull(),
+			Enabled:       types.BoolNull(),
+			AllowNoMatch:  types.BoolNull(),
+			AuditMatch:    types.BoolNull(),
+			AuditNoMatch:  types.BoolNull(),
+		}
+	}
+	if restrictions == nil {
+		restrictions = &commandRestrictions{}
+	}
+
+	var whitelists []WhitelistGrantModel
+	for _, whitelist := range restrictions.Whitelists {
+		var roles []RoleRefResourceModel
+		for _, role := range whitelist.Roles {
+			roles = append(roles, RoleRefResourceModel{ID: types.StringValue(role.ID)})
+		}
+		whitelists = append(whitelists, WhitelistGrantModel{
+			Whitelist: WhitelistRefResourceModel{ID: types.StringValue(whitelist.Whitelist.ID)},
+			Roles:     roles,
+		})
+	}
+
+	model := &CommandRestrictionsModel{
+		RShellVariant: priorString(restrictions.RShellVariant, prior.RShellVariant),
+		Banner:        priorString(restrictions.Banner, prior.Banner),
+		Enabled:       optionalBool(restrictions.Enabled, prior.Enabled),
+		AllowNoMatch:  optionalBool(restrictions.AllowNoMatch, prior.AllowNoMatch),
+		AuditMatch:    optionalBool(restrictions.AuditMatch, prior.AuditMatch),
+		AuditNoMatch:  optionalBool(restrictions.AuditNoMatch, prior.AuditNoMatch),
+		Whitelists:    whitelists,
+	}
+	if id := restrictions.DefaultWhitelist.ID; id != "" || prior.DefaultWhitelist != nil {
+		model.DefaultWhitelist = &WhitelistRefResourceModel{ID: types.StringValue(id)}
+	}
+	return model
+}
+
+// newPasswordRotationModel returns the model of the password rotation
+// settings, nil when the host has none.
+func newPasswordRotationModel(rotation *passwordRotation) *PasswordRotationModel {
+	if rotation == nil || *rotation == (passwordRotation{}) {
+		return nil
+	}
+
+	return &PasswordRotationModel{
+		OperatingSystem:  types.StringValue(rotation.OperatingSystem),
+		WINRMAddress:     types.StringValue(rotation.WinRMAddress),
+		WINRMPort:        types.Int64Value(int64(rotation.WinRMPort)),
+		Protocol:         types.StringValue(rotation.Protocol),
+		PasswordPolicyID: types.StringValue(rotation.PasswordPolicyID),
+		ScriptTemplateID: types.StringValue(rotation.ScriptTemplateID),
+		UseMainAccount:   types.BoolValue(rotation.UseMainAccount),
+	}
+}
+
+// payload returns the host described by the model.
+func (data *HostResourceModel) payload(ctx context.Context) (*privxHost, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var scopePayload []string
+	diags.Append(data.Scope.ElementsAs(ctx, &scopePayload, false)...)
+
+	var tagsPayload []string
+	diags.Append(data.Tags.ElementsAs(ctx, &tagsPayload, false)...)
+
+	var addressesPayload []hoststore.Address
+	diags.Append(data.Addresses.ElementsAs(ctx, &addressesPayload, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var servicesPayload []hostService
 	for _, service := range data.Services {
 		servicesPayload = append(servicesPayload,
-			hoststore.Service{
-				Scheme:  hoststore.Scheme(service.Scheme.ValueString()),
-				Address: hoststore.Address(service.Address.ValueString()),
-				Port:    int(service.Port.ValueInt64()),
-				// UseForPasswordRotation: service.UseForPasswordRotation.ValueBool(), // FIXME: Not implemented in privx-sdk-go v1.29.0
+			hostService{
+				Service: hoststore.Service{
+					Scheme:  hoststore.Scheme(service.Scheme.ValueString()),
+					Address: hoststore.Address(service.Address.ValueString()),
+					Port:    int(service.Port.ValueInt64()),
+				},
+				UseForPasswordRotation: service.UseForPasswordRotation.ValueBool(),
 			})
 	}
 
-	var principalsPayload []hoststore.Principal
+	var principalsPayload []hostPrincipal
 	for _, principal := range data.Principals {
 		var rolesPayload []rolestore.RoleRef
 		for _, role := range principal.Roles {
@@ -732,26 +1225,30 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 					ID: role.ID.ValueString(),
 				})
 		}
-		/* FIXME: object application not implemented, principal only takes []string.
-		var applicationsPayload []hoststore.Application
+		applicationsPayload := []hostApplication{}
 		for _, application := range principal.Applications {
 			applicationsPayload = append(applicationsPayload,
-			hoststore.Application {
-				Name: application.Name.ValueString(),
-				Application: application.Applictaion.ValueString(),
-				Arguments: application.Arguments.ValueString(),
-				WorkingDirectory: application.WorkingDirectory.ValueString(),
-			})
+				hostApplication{
+					Name:             application.Name.ValueString(),
+					Application:      application.Application.ValueString(),
+					Arguments:        application.Arguments.ValueString(),
+					WorkingDirectory: application.WorkingDirectory.ValueString(),
+				})
 		}
-		*/
 
 		principalsPayload = append(principalsPayload,
-			hoststore.Principal{
-				ID:             principal.ID.ValueString(),
-				Source:         "terraform",
-				UseUserAccount: principal.UseUserAccount.ValueBool(),
-				Passphrase:     principal.Passphrase.ValueString(),
-				Roles:          rolesPayload,
+			hostPrincipal{
+				Principal: hoststore.Principal{
+					ID:             principal.ID.ValueString(),
+					UseUserAccount: principal.UseUserAccount.ValueBool(),
+					Passphrase:     principal.Passphrase.ValueString(),
+					Roles:          rolesPayload,
+				},
+				Rotate:                 principal.Rotate.ValueBool(),
+				UseForPasswordRotation: principal.UseForPasswordRotation.ValueBool(),
+				Applications:           applicationsPayload,
+				ServiceOptions:         principal.ServiceOptions.payload(),
+				CommandRestrictions:    principal.CommandRestrictions.payload(),
 			})
 	}
 
@@ -763,35 +1260,88 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 			})
 	}
 
-	host := hoststore.Host{
-		AccessGroupID:       data.AccessGroupID.ValueString(),
-		ExternalID:          data.ExternalID.ValueString(),
-		InstanceID:          data.InstanceID.ValueString(),
-		Name:                data.Name.ValueString(),
-		ContactAdress:       data.ContactAddress.ValueString(),
-		CloudProvider:       data.CloudProvider.ValueString(),
-		CloudProviderRegion: data.CloudProviderRegion.ValueString(),
-		DistinguishedName:   data.DistinguishedName.ValueString(),
-		Organization:        data.Organization.ValueString(),
-		OrganizationUnit:    data.OrganizationUnit.ValueString(),
-		Zone:                data.Zone.ValueString(),
-		HostType:            data.HostType.ValueString(),
-		HostClassification:  data.HostClassification.ValueString(),
-		Comment:             data.Comment.ValueString(),
-		Tofu:                data.Tofu.ValueBool(),
-		StandAlone:          data.StandAlone.ValueBool(),
-		Audit:               data.Audit.ValueBool(),
-		Scope:               scopePayload,
-		Tags:                tagsPayload,
-		Addresses:           addressesPayload,
-		Services:            servicesPayload,
-		Principals:          principalsPayload,
-		PublicKeys:          publicKeysPayload,
-	}
-
-	tflog.Debug(ctx, fmt.Sprintf("hoststore.Host model used: %+v", host))
-
-	hostID, err := r.client.CreateHost(host)
+	var rotationPayload *passwordRotation
+	if rotation := data.PasswordRotation; rotation != nil {
+		rotationPayload = &passwordRotation{
+			UseMainAccount:   rotation.UseMainAccount.ValueBool(),
+			OperatingSystem:  rotation.OperatingSystem.ValueString(),
+			WinRMAddress:     rotation.WINRMAddress.ValueString(),
+			WinRMPort:        int(rotation.WINRMPort.ValueInt64()),
+			Protocol:         rotation.Protocol.ValueString(),
+			PasswordPolicyID: rotation.PasswordPolicyID.ValueString(),
+			ScriptTemplateID: rotation.ScriptTemplateID.ValueString(),
+		}
+	}
+
+	return &privxHost{
+		Host: hoststore.Host{
+			AccessGroupID:       data.AccessGroupID.ValueString(),
+			ExternalID:          data.ExternalID.ValueString(),
+			InstanceID:          data.InstanceID.ValueString(),
+			Name:                data.Name.ValueString(),
+			ContactAdress:       data.ContactAddress.ValueString(),
+			CloudProvider:       data.CloudProvider.ValueString(),
+			CloudProviderRegion: data.CloudProviderRegion.ValueString(),
+			DistinguishedName:   data.DistinguishedName.ValueString(),
+			Organization:        data.Organization.ValueString(),
+			OrganizationUnit:    data.OrganizationUnit.ValueString(),
+			Zone:                data.Zone.ValueString(),
+			HostType:            data.HostType.ValueString(),
+			HostClassification:  data.HostClassification.ValueString(),
+			Comment:             data.Comment.ValueString(),
+			Tofu:                data.Tofu.ValueBool(),
+			StandAlone:          data.StandAlone.ValueBool(),
+			Audit:               data.Audit.ValueBool(),
+			Scope:               scopePayload,
+			Tags:                tagsPayload,
+			Addresses:           addressesPayload,
+			PublicKeys:          publicKeysPayload,
+		},
+		Services:                servicesPayload,
+		Principals:              principalsPayload,
+		PasswordRotationEnabled: data.PasswordRotationEnabled.ValueBool(),
+		PasswordRotation:        rotationPayload,
+		CertificateTemplate:     data.CertificateTemplate.ValueString(),
+		HostCertificateRaw:      data.HostCertificateRaw.ValueString(),
+	}, diags
+}
+
+func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data HostResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Loaded host type data", map[string]interface{}{
+		"data": utils.Redacted(data),
+	})
+
+	resp.Diagnostics.Append(r.checkCertificateTemplate(data.CertificateTemplate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, diags := data.payload(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i := range host.Principals {
+		host.Principals[i].Source = "terraform"
+	}
+
+	tflog.Debug(ctx, "privxHost model used: "+utils.Redacted(host))
+
+	hostID, err := createHost(r.connector, host)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -824,7 +1374,17 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	host, err := r.client.Host(data.ID.ValueString())
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, err := getHost(r.connector, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "host not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read host, got error: %s", err))
 		return
@@ -845,8 +1405,10 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.HostClassification = types.StringValue(host.HostClassification)
 	data.Comment = types.StringValue(host.Comment)
 	data.Tofu = types.BoolValue(host.Tofu)
-	data.StandAlone = types.BoolValue(host.Tofu)
+	data.StandAlone = types.BoolValue(host.StandAlone)
 	data.Audit = types.BoolValue(host.Audit)
+	data.CertificateTemplate = optionalString(host.CertificateTemplate)
+	data.HostCertificateRaw = priorCertificates(host.HostCertificateRaw, data.HostCertificateRaw)
 
 	scope, diags := types.SetValueFrom(ctx, data.Scope.ElementType(ctx), host.Scope)
 	if diags.HasError() {
@@ -868,38 +1430,55 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	var services []ServiceModel
 	for _, s := range host.Services {
+		prior := ServiceModel{UseForPasswordRotation: types.BoolNull()}
+		for _, ds := range data.Services {
+			if ds.Scheme.ValueString() == string(s.Scheme) && ds.Address.ValueString() == string(s.Address) && ds.Port.ValueInt64() == int64(s.Port) {
+				prior = ds
+			}
+		}
 		services = append(services, ServiceModel{
 			Scheme:  types.StringValue(string(s.Scheme)),
 			Address: types.StringValue(string(s.Address)),
 			Port:    types.Int64Value(int64(s.Port)),
-			// UseForPasswordRotation: types.StringValue(s.UseForPasswordRotation), // FIXME: Not implemented in privx-sdk-go v1.29.0
+
+			UseForPasswordRotation: optionalBool(s.UseForPasswordRotation, prior.UseForPasswordRotation),
 		})
 	}
 	data.Services = services
 
 	var principals []PrincipalModel
 	for _, p := range host.Principals {
+		if p.Source == hostPrincipalSource {
+			continue
+		}
 		var roles []RoleRefResourceModel
 		for _, r := range p.Roles {
 			roles = append(roles, RoleRefResourceModel{
 				ID: types.StringValue(r.ID),
 			})
 		}
-		var passphrase string
+		prior := PrincipalModel{
+			Passphrase:             types.StringNull(),
+			UseUserAccount:         types.BoolNull(),
+			Rotate:                 types.BoolNull(),
+			UseForPasswordRotation: types.BoolNull(),
+		}
 		for _, dp := range data.Principals {
 			if dp.ID.ValueString() == p.ID {
-				passphrase = dp.Passphrase.ValueString()
+				prior = dp
 			}
 		}
 		principals = append(principals, PrincipalModel{
 			ID:             types.StringValue(p.ID),
-			Passphrase:     types.StringValue(passphrase),
+			Passphrase:     types.StringValue(prior.Passphrase.ValueString()),
 			UseUserAccount: types.BoolValue(p.UseUserAccount),
-			// Rotate:     types.BoolValue(p.Rotate), // FIXME: Not implemented in privx-sdk-go v1.29.0
-			// UseForPasswordRotation:     types.BoolValue(p.UseForPasswordRotation), // FIXME: Not implemented in privx-sdk-go v1.29.0
-			// ServiceOptions: serviceOptions, // FIXME: Not implemented in privx-sdk-go v1.29.0
-			// CommandRestrictions: commandRestrictions, // FIXME: Not implemented in privx-sdk-go v1.29.0
-			Roles: roles,
+			Rotate:         optionalBool(p.Rotate, prior.Rotate),
+
+			UseForPasswordRotation: optionalBool(p.UseForPasswordRotation, prior.UseForPasswordRotation),
+			ServiceOptions:         newServiceOptionsModel(p.ServiceOptions, prior.ServiceOptions),
+			CommandRestrictions:    newCommandRestrictionsModel(p.CommandRestrictions, prior.CommandRestrictions),
+			Applications:           newApplicationModels(p.Applications, prior.Applications),
+			Roles:                  roles,
 		})
 	}
 	data.Principals = principals
@@ -912,8 +1491,11 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 	data.PublicKeys = publickeys
 
+	data.PasswordRotationEnabled = types.BoolValue(host.PasswordRotationEnabled)
+	data.PasswordRotation = newPasswordRotationModel(host.PasswordRotation)
+
 	tflog.Debug(ctx, "Storing host type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -929,104 +1511,40 @@ func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	var scopePayload []string
-	resp.Diagnostics.Append(data.Scope.ElementsAs(ctx, &scopePayload, false)...)
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tagsPayload := make([]string, len(data.Tags.Elements()))
-	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tagsPayload, false)...)
+	resp.Diagnostics.Append(r.checkCertificateTemplate(data.CertificateTemplate)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	addressesPayload := make([]hoststore.Address, len(data.Addresses.Elements()))
-	resp.Diagnostics.Append(data.Addresses.ElementsAs(ctx, &addressesPayload, false)...)
+	host, diags := data.payload(ctx)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var servicesPayload []hoststore.Service
-	for _, service := range data.Services {
-		servicesPayload = append(servicesPayload,
-			hoststore.Service{
-				Scheme:  hoststore.Scheme(service.Scheme.ValueString()),
-				Address: hoststore.Address(service.Address.ValueString()),
-				Port:    int(service.Port.ValueInt64()),
-				// UseForPasswordRotation: service.UseForPasswordRotation.ValueBool(), // FIXME: Not implemented in privx-sdk-go v1.29.0
-			})
-	}
+	// Keep the principals managed by privx_host_principal resources
+	hostsMu.Lock()
+	defer hostsMu.Unlock()
 
-	var principalsPayload []hoststore.Principal
-	for _, principal := range data.Principals {
-		var rolesPayload []rolestore.RoleRef
-		for _, role := range principal.Roles {
-			rolesPayload = append(rolesPayload,
-				rolestore.RoleRef{
-					ID: role.ID.ValueString(),
-				})
-		}
-		/* FIXME: object application not implemented, principal only takes []string.
-		var applicationsPayload []hoststore.Application
-		for _, application := range principal.Applications {
-			applicationsPayload = append(applicationsPayload,
-			hoststore.Application {
-				Name: application.Name.ValueString(),
-				Application: application.Applictaion.ValueString(),
-				Arguments: application.Arguments.ValueString(),
-				WorkingDirectory: application.WorkingDirectory.ValueString(),
-			})
+	current, err := getHost(r.connector, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read host, got error: %s", err))
+		return
+	}
+	for _, principal := range current.Principals {
+		if principal.Source == hostPrincipalSource && !slices.ContainsFunc(host.Principals, func(p hostPrincipal) bool { return p.ID == principal.ID }) {
+			host.Principals = append(host.Principals, principal)
 		}
-		*/
-		principalsPayload = append(principalsPayload,
-			hoststore.Principal{
-				ID:             principal.ID.ValueString(),
-				UseUserAccount: principal.UseUserAccount.ValueBool(),
-				Passphrase:     principal.Passphrase.ValueString(),
-				Roles:          rolesPayload,
-			})
 	}
 
-	var publicKeysPayload []hoststore.SSHPublicKey
-	for _, SSHKey := range data.PublicKeys {
-		publicKeysPayload = append(publicKeysPayload,
-			hoststore.SSHPublicKey{
-				Key: SSHKey.Key.ValueString(),
-			})
-	}
+	tflog.Debug(ctx, "privxHost model used: "+utils.Redacted(host))
 
-	host := hoststore.Host{
-		AccessGroupID:       data.AccessGroupID.ValueString(),
-		ExternalID:          data.ExternalID.ValueString(),
-		InstanceID:          data.InstanceID.ValueString(),
-		Name:                data.Name.ValueString(),
-		ContactAdress:       data.ContactAddress.ValueString(),
-		CloudProvider:       data.CloudProvider.ValueString(),
-		CloudProviderRegion: data.CloudProviderRegion.ValueString(),
-		DistinguishedName:   data.DistinguishedName.ValueString(),
-		Organization:        data.Organization.ValueString(),
-		OrganizationUnit:    data.OrganizationUnit.ValueString(),
-		Zone:                data.Zone.ValueString(),
-		HostType:            data.HostType.ValueString(),
-		HostClassification:  data.HostClassification.ValueString(),
-		Comment:             data.Comment.ValueString(),
-		Tofu:                data.Tofu.ValueBool(),
-		StandAlone:          data.StandAlone.ValueBool(),
-		Audit:               data.Audit.ValueBool(),
-		Scope:               scopePayload,
-		Tags:                tagsPayload,
-		Addresses:           addressesPayload,
-		Services:            servicesPayload,
-		Principals:          principalsPayload,
-		PublicKeys:          publicKeysPayload,
-	}
-
-	tflog.Debug(ctx, fmt.Sprintf("hoststore.Host model used: %+v", host))
-
-	err := r.client.UpdateHost(
-		data.ID.ValueString(),
-		&host)
+	err = updateHost(r.connector, data.ID.ValueString(), host)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update host, got error: %s", err))
@@ -1047,6 +1565,11 @@ func (r *HostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteHost(data.ID.ValueString())
 
 	if err != nil {
@@ -1056,5 +1579,5 @@ func (r *HostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *HostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.importState(ctx, path.Root("id"), req, resp)
 }
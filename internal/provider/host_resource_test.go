@@ -0,0 +1,703 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_host", "id", func(connector restapi.Connector, id string) error {
+			_, err := hoststore.New(connector).Host(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccHostResourceConfig(name, "10.0.0.10", "root"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "common_name", name),
+					resource.TestCheckResourceAttr("privx_host.test", "addresses.#", "1"),
+					resource.TestCheckTypeSetElemAttr("privx_host.test", "addresses.*", "10.0.0.10"),
+					resource.TestCheckResourceAttr("privx_host.test", "services.#", "1"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{"principal": "root"}),
+					resource.TestCheckResourceAttrSet("privx_host.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_host.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccHostResourceConfig(name, "10.0.0.11", "admin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("privx_host.test", "addresses.*", "10.0.0.11"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{"principal": "admin"}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostResourcePasswordRotation(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHostResourcePasswordRotationConfig(name, false, "SSH", false),
+				ExpectError: regexp.MustCompile("requires password_rotation_enabled"),
+			},
+			{
+				Config:      testAccHostResourcePasswordRotationConfig(name, true, "SSH", true),
+				ExpectError: regexp.MustCompile("winrm_port only applies to the WINRM protocol"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccHostResourcePasswordRotationConfig(name, true, "SSH", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "password_rotation_enabled", "true"),
+					resource.TestCheckResourceAttr("privx_host.test", "password_rotation.operating_system", "LINUX"),
+					resource.TestCheckResourceAttr("privx_host.test", "password_rotation.protocol", "SSH"),
+					resource.TestCheckResourceAttr("privx_host.test", "password_rotation.use_main_account", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "services.*", map[string]string{"use_for_password_rotation": "true"}),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{
+						"principal":                 "root",
+						"passphrase":                "initial",
+						"use_for_password_rotation": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{
+						"principal":        "admin",
+						"passphrase":       "",
+						"use_user_account": "false",
+						"rotate":           "true",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "privx_host.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"principals"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccHostResourcePasswordRotationConfig(name, true, "WINRM", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "password_rotation.operating_system", "WINDOWS"),
+					resource.TestCheckResourceAttr("privx_host.test", "password_rotation.winrm_port", "5986"),
+				),
+			},
+			// Disabling the rotation
+			{
+				Config: testAccHostResourceConfig(name, "10.0.0.10", "root"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "password_rotation_enabled", "false"),
+					resource.TestCheckNoResourceAttr("privx_host.test", "password_rotation.protocol"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostResourcePrincipalDefaults(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, the configured values are kept next to
+			// the defaults of the unset attributes
+			{
+				Config: testAccHostResourcePrincipalDefaultsConfig(name, "10.0.0.10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "principals.#", "4"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{
+						"principal":        "root",
+						"passphrase":       "root-secret",
+						"use_user_account": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{
+						"principal":        "deploy",
+						"passphrase":       "deploy-secret",
+						"use_user_account": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{
+						"principal":        "app",
+						"passphrase":       "",
+						"use_user_account": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{
+						"principal":        "admin",
+						"passphrase":       "",
+						"use_user_account": "false",
+					}),
+				),
+			},
+			// Update and Read testing, planning again with the prior state
+			{
+				Config: testAccHostResourcePrincipalDefaultsConfig(name, "10.0.0.11"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.*", map[string]string{
+						"principal":  "deploy",
+						"passphrase": "deploy-secret",
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostResourceCommandRestrictions(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHostResourceCommandRestrictionsConfig(name, `enabled = true`),
+				ExpectError: regexp.MustCompile("default_whitelist is required"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccHostResourceCommandRestrictionsConfig(name, `
+        enabled        = true
+        rshell_variant = "bash"
+        banner         = "Restricted shell"
+        audit_no_match = true
+        default_whitelist = {
+          id = privx_command_whitelist.test.id
+        }
+        whitelists = [
+          {
+            whitelist = {
+              id = privx_command_whitelist.admin.id
+            }
+            roles = [
+              {
+                id = privx_role.test.id
+              },
+            ]
+          },
+        ]`) + `
+data "privx_host" "test" {
+  id = privx_host.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.command_restrictions.enabled", "true"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.command_restrictions.rshell_variant", "bash"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.command_restrictions.banner", "Restricted shell"),
+					resource.TestCheckNoResourceAttr("privx_host.test", "principals.0.command_restrictions.allow_no_match"),
+					resource.TestCheckResourceAttrPair("privx_host.test", "principals.0.command_restrictions.default_whitelist.id", "privx_command_whitelist.test", "id"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.command_restrictions.whitelists.#", "1"),
+					resource.TestCheckResourceAttrPair("privx_host.test", "principals.0.command_restrictions.whitelists.0.roles.0.id", "privx_role.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.0.command_restrictions.audit_no_match", "true"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.0.command_restrictions.allow_no_match", "false"),
+					resource.TestCheckResourceAttrPair("data.privx_host.test", "principals.0.command_restrictions.whitelists.0.whitelist.id", "privx_command_whitelist.admin", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_host.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Removing the restrictions
+			{
+				Config: testAccHostResourceCommandRestrictionsConfig(name, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("privx_host.test", "principals.0.command_restrictions.enabled"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostResourceServiceOptions(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, the options left unset stay enabled
+			{
+				Config: testAccHostResourceServiceOptionsConfig(name, `
+        ssh = {
+          file_transfer = false
+          x11           = false
+        }
+        rdp = {
+          clipboard = false
+        }`) + `
+data "privx_host" "test" {
+  id = privx_host.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.service_options.ssh.file_transfer", "false"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.service_options.ssh.x11", "false"),
+					resource.TestCheckNoResourceAttr("privx_host.test", "principals.0.service_options.ssh.shell"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.service_options.rdp.clipboard", "false"),
+					resource.TestCheckNoResourceAttr("privx_host.test", "principals.0.service_options.web.file_transfer"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.0.service_options.ssh.file_transfer", "false"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.0.service_options.ssh.shell", "true"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.0.service_options.rdp.clipboard", "false"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.0.service_options.rdp.audio", "true"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.0.service_options.web.file_transfer", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_host.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccHostResourceServiceOptionsConfig(name, `
+        web = {
+          file_transfer = false
+        }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("privx_host.test", "principals.0.service_options.ssh.file_transfer"),
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.service_options.web.file_transfer", "false"),
+				),
+			},
+			// Removing the options enables every feature again
+			{
+				Config: testAccHostResourceServiceOptionsConfig(name, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("privx_host.test", "principals.0.service_options.web.file_transfer"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostResourceApplications(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHostResourceApplicationsConfig(name, "notepad", "notepad"),
+				ExpectError: regexp.MustCompile("several applications named"),
+			},
+			// Create and Read testing, along with principals and lookups going
+			// through the applications
+			{
+				Config: testAccHostResourceApplicationsConfig(name, "notepad", "mmc") + `
+resource "privx_host_principal" "test" {
+  host_id   = privx_host.test.id
+  principal = "operator"
+}
+
+data "privx_host" "test" {
+  common_name = privx_host.test.common_name
+  depends_on  = [privx_host_principal.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "principals.0.applications.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.0.applications.*", map[string]string{
+						"name":              "notepad",
+						"application":       `C:\Windows\System32\notepad.exe`,
+						"working_directory": `C:\Users\Public`,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_host.test", "principals.0.applications.*", map[string]string{
+						"name":      "mmc",
+						"arguments": "services.msc",
+					}),
+					resource.TestCheckResourceAttr("privx_host_principal.test", "principal", "operator"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "principals.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.privx_host.test", "principals.*.applications.*", map[string]string{
+						"name":              "mmc",
+						"arguments":         "services.msc",
+						"working_directory": "",
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_host.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostResourceCertificate(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+	certificate := testAccHostCertificate(t, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHostResourceCertificateConfig(name, "ssh-host", "not a certificate"),
+				ExpectError: regexp.MustCompile("Invalid Host Certificate"),
+			},
+			{
+				Config:      testAccHostResourceCertificateConfig(name, "unknown", certificate),
+				ExpectError: regexp.MustCompile("No certificate template is named"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccHostResourceCertificateConfig(name, "ssh-host", certificate) + `
+data "privx_host" "test" {
+  id = privx_host.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_host.test", "certificate_template", "ssh-host"),
+					resource.TestCheckResourceAttr("privx_host.test", "host_certificate_raw", certificate),
+					resource.TestCheckResourceAttr("privx_host.test", "tofu", "false"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "certificate_template", "ssh-host"),
+					resource.TestCheckResourceAttr("data.privx_host.test", "host_certificate_raw", certificate),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_host.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Removing the certificate
+			{
+				Config: testAccHostResourceConfig(name, "10.0.0.10", "root"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("privx_host.test", "certificate_template"),
+					resource.TestCheckNoResourceAttr("privx_host.test", "host_certificate_raw"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccHostDataSources(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfig(name, "10.0.0.10", "root") + `
+data "privx_host" "by_id" {
+  id = privx_host.test.id
+}
+
+data "privx_host" "by_address" {
+  common_name = privx_host.test.common_name
+  address     = "10.0.0.10"
+}
+
+data "privx_hosts" "test" {
+  keywords = privx_host.test.common_name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.privx_host.by_id", "common_name", "privx_host.test", "common_name"),
+					resource.TestCheckResourceAttrPair("data.privx_host.by_address", "id", "privx_host.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_hosts.test", "hosts.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_hosts.test", "hosts.0.id", "privx_host.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHostResourceConfig(name, address, principal string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_role" "test" {
+  name            = %[1]q
+  access_group_id = privx_access_group.test.id
+}
+
+resource "privx_host" "test" {
+  common_name     = %[1]q
+  access_group_id = privx_access_group.test.id
+  addresses       = [%[2]q]
+
+  services = [
+    {
+      service = "SSH"
+      address = %[2]q
+      port    = 22
+    },
+  ]
+
+  principals = [
+    {
+      principal = %[3]q
+      roles = [
+        {
+          id = privx_role.test.id
+        },
+      ]
+    },
+  ]
+}
+`, name, address, principal)
+}
+
+func testAccHostResourcePasswordRotationConfig(name string, enabled bool, protocol string, winrm bool) string {
+	operatingSystem, winrmPort := "LINUX", ""
+	if winrm {
+		operatingSystem, winrmPort = "WINDOWS", "winrm_port = 5986"
+	}
+
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_host" "test" {
+  common_name     = %[1]q
+  access_group_id = privx_access_group.test.id
+  addresses       = ["10.0.0.10"]
+
+  services = [
+    {
+      service                   = "SSH"
+      address                   = "10.0.0.10"
+      port                      = 22
+      use_for_password_rotation = true
+    },
+  ]
+
+  principals = [
+    {
+      principal                 = "root"
+      passphrase                = "initial"
+      use_for_password_rotation = true
+    },
+    {
+      principal = "admin"
+      rotate    = true
+    },
+  ]
+
+  password_rotation_enabled = %[2]t
+  password_rotation = {
+    use_main_account   = true
+    operating_system   = %[4]q
+    protocol           = %[3]q
+    password_policy_id = "6b3a8f1e-2d4c-4e5f-9a7b-1c2d3e4f5a6b"
+    script_template_id = "0c9d8e7f-6a5b-4c3d-2e1f-0a9b8c7d6e5f"
+    %[5]s
+  }
+}
+`, name, enabled, protocol, operatingSystem, winrmPort)
+}
+
+func testAccHostResourcePrincipalDefaultsConfig(name, address string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_host" "test" {
+  common_name     = %[1]q
+  access_group_id = privx_access_group.test.id
+  addresses       = [%[2]q]
+
+  principals = [
+    {
+      principal        = "root"
+      passphrase       = "root-secret"
+      use_user_account = true
+    },
+    {
+      principal  = "deploy"
+      passphrase = "deploy-secret"
+    },
+    {
+      principal        = "app"
+      use_user_account = true
+    },
+    {
+      principal = "admin"
+    },
+  ]
+}
+`, name, address)
+}
+
+func testAccHostResourceCommandRestrictionsConfig(name, restrictions string) string {
+	if restrictions != "" {
+		restrictions = "command_restrictions = {" + restrictions + "\n      }"
+	}
+
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_role" "test" {
+  name            = %[1]q
+  access_group_id = privx_access_group.test.id
+}
+
+resource "privx_command_whitelist" "test" {
+  name               = %[1]q
+  whitelist_patterns = ["systemctl status *"]
+}
+
+resource "privx_command_whitelist" "admin" {
+  name               = "%[1]s-admin"
+  whitelist_patterns = ["systemctl *"]
+}
+
+resource "privx_host" "test" {
+  common_name     = %[1]q
+  access_group_id = privx_access_group.test.id
+  addresses       = ["10.0.0.10"]
+
+  principals = [
+    {
+      principal = "app"
+      %[2]s
+    },
+  ]
+}
+`, name, restrictions)
+}
+
+func testAccHostResourceServiceOptionsConfig(name, options string) string {
+	if options != "" {
+		options = "service_options = {" + options + "\n      }"
+	}
+
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_host" "test" {
+  common_name     = %[1]q
+  access_group_id = privx_access_group.test.id
+  addresses       = ["10.0.0.10"]
+
+  principals = [
+    {
+      principal = "auditor"
+      %[2]s
+    },
+  ]
+}
+`, name, options)
+}
+
+func testAccHostResourceApplicationsConfig(name, notepad, mmc string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_host" "test" {
+  common_name     = %[1]q
+  access_group_id = privx_access_group.test.id
+  addresses       = ["10.0.0.20"]
+
+  principals = [
+    {
+      principal = "Administrator"
+      applications = [
+        {
+          name              = %[2]q
+          application       = "C:\\Windows\\System32\\notepad.exe"
+          working_directory = "C:\\Users\\Public"
+        },
+        {
+          name        = %[3]q
+          application = "C:\\Windows\\System32\\mmc.exe"
+          arguments   = "services.msc"
+        },
+      ]
+    },
+  ]
+}
+`, name, notepad, mmc)
+}
+
+// testAccHostCertificate returns a self-signed PEM encoded host certificate.
+func testAccHostCertificate(t *testing.T, name string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testAccHostResourceCertificateConfig(name, template, certificate string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_host" "test" {
+  common_name          = %[1]q
+  access_group_id      = privx_access_group.test.id
+  addresses            = ["10.0.0.10"]
+  tofu                 = false
+  certificate_template = %[2]q
+  host_certificate_raw = %[3]q
+}
+`, name, template, certificate)
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostsDataSource{}
+
+func NewHostsDataSource() datasource.DataSource {
+	return &HostsDataSource{}
+}
+
+// HostsDataSource defines the data source implementation.
+type HostsDataSource struct {
+	endpoints
+	connector restapi.Connector
+}
+
+// HostsDataSourceModel describes the data source data model.
+type (
+	HostSummaryModel struct {
+		ID                  types.String `tfsdk:"id"`
+		Name                types.String `tfsdk:"common_name"`
+		AccessGroupID       types.String `tfsdk:"access_group_id"`
+		ExternalID          types.String `tfsdk:"external_id"`
+		InstanceID          types.String `tfsdk:"instance_id"`
+		SourceID            types.String `tfsdk:"source_id"`
+		CloudProvider       types.String `tfsdk:"cloud_provider"`
+		CloudProviderRegion types.String `tfsdk:"cloud_provider_region"`
+		Zone                types.String `tfsdk:"zone"`
+		HostClassification  types.String `tfsdk:"host_classification"`
+		Tags                types.Set    `tfsdk:"tags"`
+		Addresses           types.Set    `tfsdk:"addresses"`
+	}
+
+	HostsDataSourceModel struct {
+		Keywords             types.String       `tfsdk:"keywords"`
+		SourceID             types.String       `tfsdk:"source_id"`
+		Tags                 types.Set          `tfsdk:"tags"`
+		AccessGroupIDs       types.Set          `tfsdk:"access_group_ids"`
+		CloudProviders       types.Set          `tfsdk:"cloud_providers"`
+		CloudProviderRegions types.Set          `tfsdk:"cloud_provider_regions"`
+		Zones                types.Set          `tfsdk:"zones"`
+		HostClassifications  types.Set          `tfsdk:"host_classifications"`
+		Hosts                []HostSummaryModel `tfsdk:"hosts"`
+		Endpoint             types.String       `tfsdk:"endpoint"`
+	}
+)
+
+func (d *HostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts"
+}
+
+func (d *HostsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Hosts data source. Lists the hosts matching all the given filters, every host when no filter is set.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
+			"keywords": schema.StringAttribute{
+				MarkdownDescription: "Keywords to search hosts by",
+				Optional:            true,
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "Import-source identifier of the hosts",
+				Optional:            true,
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Host tags",
+				Optional:            true,
+			},
+			"access_group_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Access group IDs of the hosts",
+				Optional:            true,
+			},
+			"cloud_providers": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Cloud providers the hosts reside in",
+				Optional:            true,
+			},
+			"cloud_provider_regions": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Cloud provider regions the hosts reside in",
+				Optional:            true,
+			},
+			"zones": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Equipment zones (development, production, user acceptance testing, ..)",
+				Optional:            true,
+			},
+			"host_classifications": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Classifications (Windows desktop, Windows server, AIX, Linux RH, ..)",
+				Optional:            true,
+			},
+			"hosts": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching hosts",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Host ID",
+							Computed:            true,
+						},
+						"common_name": schema.StringAttribute{
+							MarkdownDescription: "X.500 Common name",
+							Computed:            true,
+						},
+						"access_group_id": schema.StringAttribute{
+							MarkdownDescription: "Host's access group",
+							Computed:            true,
+						},
+						"external_id": schema.StringAttribute{
+							MarkdownDescription: "The equipment ID from the originating equipment store",
+							Computed:            true,
+						},
+						"instance_id": schema.StringAttribute{
+							MarkdownDescription: "The instance ID from the originating cloud service",
+							Computed:            true,
+						},
+						"source_id": schema.StringAttribute{
+							MarkdownDescription: "Import-source identifier of the host",
+							Computed:            true,
+						},
+						"cloud_provider": schema.StringAttribute{
+							MarkdownDescription: "The cloud provider the host resides in",
+							Computed:            true,
+						},
+						"cloud_provider_region": schema.StringAttribute{
+							MarkdownDescription: "The cloud provider region the host resides in",
+							Computed:            true,
+						},
+						"zone": schema.StringAttribute{
+							MarkdownDescription: "Equipment zone",
+							Computed:            true,
+						},
+						"host_classification": schema.StringAttribute{
+							MarkdownDescription: "Host classification",
+							Computed:            true,
+						},
+						"tags": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "Host tags",
+							Computed:            true,
+						},
+						"addresses": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "Host addresses",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *HostsDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating HostStore client", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	d.connector = connector
+	return diags
+}
+
+func (d *HostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	searchObject := hoststore.HostSearchObject{
+		Keywords: data.Keywords.ValueString(),
+		SourceID: data.SourceID.ValueString(),
+	}
+	for _, filter := range []struct {
+		set    types.Set
+		target *[]string
+	}{
+		{data.Tags, &searchObject.Tags},
+		{data.AccessGroupIDs, &searchObject.AccessGroupIDs},
+		{data.CloudProviders, &searchObject.CloudProviders},
+		{data.CloudProviderRegions, &searchObject.CloudProviderRegions},
+		{data.Zones, &searchObject.Zone},
+		{data.HostClassifications, &searchObject.HostClassification},
+	} {
+		if filter.set.IsNull() {
+			continue
+		}
+		resp.Diagnostics.Append(filter.set.ElementsAs(ctx, filter.target, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, err := searchHosts(d.connector, &searchObject)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search hosts, got error: %s", err))
+		return
+	}
+
+	data.Hosts = []HostSummaryModel{}
+	for _, host := range hosts {
+		tags, diags := types.SetValueFrom(ctx, types.StringType, host.Tags)
+		resp.Diagnostics.Append(diags...)
+		addresses, diags := types.SetValueFrom(ctx, types.StringType, host.Addresses)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Hosts = append(data.Hosts, HostSummaryModel{
+			ID:                  types.StringValue(host.ID),
+			Name:                types.StringValue(host.Name),
+			AccessGroupID:       types.StringValue(host.AccessGroupID),
+			ExternalID:          types.StringValue(host.ExternalID),
+			InstanceID:          types.StringValue(host.InstanceID),
+			SourceID:            types.StringValue(host.SourceID),
+			CloudProvider:       types.StringValue(host.CloudProvider),
+			CloudProviderRegion: types.StringValue(host.CloudProviderRegion),
+			Zone:                types.StringValue(host.Zone),
+			HostClassification:  types.StringValue(host.HostClassification),
+			Tags:                tags,
+			Addresses:           addresses,
+		})
+	}
+
+	tflog.Debug(ctx, "Storing hosts into the state", map[string]interface{}{
+		"hostCount": len(data.Hosts),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LocalUserResource{}
+var _ resource.ResourceWithImportState = &LocalUserResource{}
+
+func NewLocalUserResource() resource.Resource {
+	return &LocalUserResource{}
+}
+
+// LocalUserResource defines the resource implementation.
+type LocalUserResource struct {
+	endpoints
+	client    *userstore.UserStore
+	roleStore *rolestore.RoleStore
+	connector restapi.Connector
+}
+
+// localUser is a userstore.LocalUser which only carries its password when
+// set, as PrivX would otherwise set an empty password.
+type localUser struct {
+	userstore.LocalUser
+	Password *userstore.Password `json:"password,omitempty"`
+}
+
+// LocalUserResourceModel describes the resource data model.
+type LocalUserResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Username  types.String `tfsdk:"username"`
+	Email     types.String `tfsdk:"email"`
+	FullName  types.String `tfsdk:"full_name"`
+	Tags      types.Set    `tfsdk:"tags"`
+	Password  types.String `tfsdk:"password"`
+	Principal types.String `tfsdk:"principal"`
+	Source    types.String `tfsdk:"source"`
+	Endpoint  types.String `tfsdk:"endpoint"`
+}
+
+func (r *LocalUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_local_user"
+}
+
+func (r *LocalUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Local user resource",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Local user ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username the user logs in with",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the user",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"full_name": schema.StringAttribute{
+				MarkdownDescription: "Full name of the user",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags of the user",
+				Optional:            true,
+				Computed:            true,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the user. PrivX never returns it: the password is only set on creation, and rotated when it changes. " +
+					"Passwords changed outside of Terraform are not detected",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "Principal name of the user, in the role store",
+				Computed:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "ID of the source of the user, in the role store",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *LocalUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *LocalUserResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	r.client = userstore.New(connector)
+	r.roleStore = rolestore.New(connector)
+	r.connector = connector
+	return diags
+}
+
+// payload returns the local user of the plan, without its password.
+func (data *LocalUserResourceModel) payload(ctx context.Context) (localUser, diag.Diagnostics) {
+	tags := []string{}
+	diags := data.Tags.ElementsAs(ctx, &tags, false)
+
+	return localUser{
+		LocalUser: userstore.LocalUser{
+			ID:       data.ID.ValueString(),
+			Username: data.Username.ValueString(),
+			Email:    data.Email.ValueString(),
+			FullName: data.FullName.ValueString(),
+			Tags:     tags,
+		},
+	}, diags
+}
+
+func createLocalUser(connector restapi.Connector, user *localUser) (string, error) {
+	var object struct {
+		ID string `json:"id"`
+	}
+
+	_, err := connector.
+		URL("/local-user-store/api/v1/users").
+		Post(user, &object)
+
+	return object.ID, err
+}
+
+func updateLocalUser(connector restapi.Connector, userID string, user *localUser) error {
+	_, err := connector.
+		URL("/local-user-store/api/v1/users/%s", url.PathEscape(userID)).
+		Put(user)
+
+	return err
+}
+
+// setRoleStoreUser sets the attributes PrivX derives from the local user in
+// the role store.
+func (data *LocalUserResourceModel) setRoleStoreUser(user *rolestore.User) {
+	data.Principal = types.StringValue(user.Principal)
+	data.Source = types.StringValue(user.Source)
+}
+
+func (r *LocalUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *LocalUserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diags := data.payload(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.Password.IsNull() {
+		payload.Password = &userstore.Password{Password: data.Password.ValueString()}
+	}
+
+	tflog.Debug(ctx, "userstore.LocalUser model used: "+utils.Redacted(payload))
+
+	localUserID, err := createLocalUser(r.connector, &payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create local user, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(localUserID)
+
+	user, err := r.roleStore.User(localUserID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local user from the role store, got error: %s", err))
+		return
+	}
+	data.setRoleStoreUser(user)
+
+	tflog.Debug(ctx, "created local user resource", map[string]interface{}{
+		"id": localUserID,
+	})
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocalUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *LocalUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.LocalUser(data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "local user not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local user, got error: %s", err))
+		return
+	}
+
+	data.Username = types.StringValue(user.Username)
+	data.Email = types.StringValue(user.Email)
+	data.FullName = types.StringValue(user.FullName)
+
+	if user.Tags == nil {
+		user.Tags = []string{}
+	}
+	tags, diags := types.SetValueFrom(ctx, types.StringType, user.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Tags = tags
+
+	roleStoreUser, err := r.roleStore.User(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local user from the role store, got error: %s", err))
+		return
+	}
+	data.setRoleStoreUser(roleStoreUser)
+
+	tflog.Debug(ctx, "Storing local user into the state", map[string]interface{}{
+		"createNewState": utils.Redacted(data),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocalUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *LocalUserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diags := data.payload(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "userstore.LocalUser model used: "+utils.Redacted(payload))
+
+	err := updateLocalUser(r.connector, data.ID.ValueString(), &payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update local user, got error: %s", err))
+		return
+	}
+
+	// Removing the password from the configuration leaves it as is
+	if !data.Password.IsNull() && !data.Password.Equal(state.Password) {
+		tflog.Debug(ctx, "Rotating local user password", map[string]interface{}{"id": data.ID.ValueString()})
+		err := r.client.UpdateLocalUserPassword(data.ID.ValueString(), &userstore.Password{Password: data.Password.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate local user password, got error: %s", err))
+			return
+		}
+	}
+
+	user, err := r.roleStore.User(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local user from the role store, got error: %s", err))
+		return
+	}
+	data.setRoleStoreUser(user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocalUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *LocalUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteLocalUser(data.ID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete local user, got error: %s", err))
+		return
+	}
+}
+
+func (r *LocalUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.importState(ctx, path.Root("id"), req, resp)
+}
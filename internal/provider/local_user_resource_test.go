@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLocalUserResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_local_user", "id", func(connector restapi.Connector, id string) error {
+			_, err := userstore.New(connector).LocalUser(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLocalUserResourceConfig(name, "Break Glass", "first-Passw0rd!", `"admin"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_local_user.test", "username", name),
+					resource.TestCheckResourceAttr("privx_local_user.test", "email", name+"@example.com"),
+					resource.TestCheckResourceAttr("privx_local_user.test", "full_name", "Break Glass"),
+					resource.TestCheckResourceAttr("privx_local_user.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("privx_local_user.test", "password", "first-Passw0rd!"),
+					resource.TestCheckResourceAttr("privx_local_user.test", "principal", name),
+					resource.TestCheckResourceAttrSet("privx_local_user.test", "source"),
+					resource.TestCheckResourceAttrSet("privx_local_user.test", "id"),
+				),
+			},
+			// ImportState testing, PrivX never returns the password
+			{
+				ResourceName:            "privx_local_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			// Update and Read testing, which rotates the password
+			{
+				Config: testAccLocalUserResourceConfig(name, "Break Glass Admin", "second-Passw0rd!", `"admin", "break-glass"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_local_user.test", "full_name", "Break Glass Admin"),
+					resource.TestCheckResourceAttr("privx_local_user.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("privx_local_user.test", "password", "second-Passw0rd!"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccLocalUsersDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLocalUserResourceConfig(name, "Break Glass", "Passw0rd!", `"admin"`) + fmt.Sprintf(`
+resource "privx_local_user" "other" {
+  username = %[1]q
+}
+
+data "privx_local_users" "prefixed" {
+  name_regex = "^${privx_local_user.test.username}"
+  depends_on = [privx_local_user.other]
+}
+
+data "privx_local_users" "tagged" {
+  name_regex = "^${privx_local_user.test.username}"
+  tag        = "admin"
+  depends_on = [privx_local_user.other]
+}
+`, name+"-other"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_local_users.prefixed", "local_users.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("data.privx_local_users.prefixed", "ids.*", "privx_local_user.test", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.privx_local_users.prefixed", "ids.*", "privx_local_user.other", "id"),
+					resource.TestCheckResourceAttr("data.privx_local_users.tagged", "local_users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_local_users.tagged", "local_users.0.id", "privx_local_user.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_local_users.tagged", "local_users.0.email", name+"@example.com"),
+					resource.TestCheckResourceAttr("data.privx_local_users.tagged", "local_users.0.principal", name),
+					resource.TestCheckResourceAttrPair("data.privx_local_users.tagged", "local_users.0.source", "privx_local_user.test", "source"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLocalUserResourceConfig(name, fullName, password, tags string) string {
+	return fmt.Sprintf(`
+resource "privx_local_user" "test" {
+  username  = %[1]q
+  email     = "%[1]s@example.com"
+  full_name = %[2]q
+  password  = %[3]q
+  tags      = [%[4]s]
+}
+`, name, fullName, password, tags)
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/api/userstore"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LocalUsersDataSource{}
+
+func NewLocalUsersDataSource() datasource.DataSource {
+	return &LocalUsersDataSource{}
+}
+
+// LocalUsersDataSource defines the data source implementation.
+type LocalUsersDataSource struct {
+	endpoints
+	client    *userstore.UserStore
+	roleStore *rolestore.RoleStore
+}
+
+// LocalUsersDataSourceModel describes the data source data model.
+type (
+	LocalUserSummaryModel struct {
+		ID        types.String `tfsdk:"id"`
+		Username  types.String `tfsdk:"username"`
+		Email     types.String `tfsdk:"email"`
+		FullName  types.String `tfsdk:"full_name"`
+		Tags      types.Set    `tfsdk:"tags"`
+		Principal types.String `tfsdk:"principal"`
+		Source    types.String `tfsdk:"source"`
+	}
+
+	LocalUsersDataSourceModel struct {
+		NameRegex  types.String            `tfsdk:"name_regex"`
+		Tag        types.String            `tfsdk:"tag"`
+		LocalUsers []LocalUserSummaryModel `tfsdk:"local_users"`
+		IDs        types.List              `tfsdk:"ids"`
+		Endpoint   types.String            `tfsdk:"endpoint"`
+	}
+)
+
+func (d *LocalUsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_local_users"
+}
+
+func (d *LocalUsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Local users data source. Lists the local users matching all the given filters, every local user when no filter is set.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the usernames of the local users match, in the RE2 syntax",
+				Optional:            true,
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "Tag of the local users",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the matching local users",
+				Computed:            true,
+			},
+			"local_users": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching local users",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Local user ID",
+							Computed:            true,
+						},
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username the user logs in with",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Email address of the user",
+							Computed:            true,
+						},
+						"full_name": schema.StringAttribute{
+							MarkdownDescription: "Full name of the user",
+							Computed:            true,
+						},
+						"tags": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "Tags of the user",
+							Computed:            true,
+						},
+						"principal": schema.StringAttribute{
+							MarkdownDescription: "Principal name of the user, in the role store",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "ID of the source of the user, in the role store",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LocalUsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *LocalUsersDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	d.client = userstore.New(connector)
+	d.roleStore = rolestore.New(connector)
+	return diags
+}
+
+// listLocalUsers returns all the local users.
+func listLocalUsers(store *userstore.UserStore) ([]userstore.LocalUser, error) {
+	const pageSize = 100
+
+	var users []userstore.LocalUser
+	for offset := 0; ; offset += pageSize {
+		page, err := store.LocalUsers(offset, pageSize, "", "")
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) < pageSize {
+			return users, nil
+		}
+	}
+}
+
+func (d *LocalUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LocalUsersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression",
+				fmt.Sprintf("Unable to parse name_regex, got error: %s", err))
+			return
+		}
+	}
+
+	users, err := listLocalUsers(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list local users, got error: %s", err))
+		return
+	}
+
+	data.LocalUsers = []LocalUserSummaryModel{}
+	ids := []string{}
+	for _, user := range users {
+		if nameRegex != nil && !nameRegex.MatchString(user.Username) {
+			continue
+		}
+		if !data.Tag.IsNull() && !slices.Contains(user.Tags, data.Tag.ValueString()) {
+			continue
+		}
+
+		roleStoreUser, err := d.roleStore.User(user.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read local user %s from the role store, got error: %s", user.ID, err))
+			return
+		}
+
+		if user.Tags == nil {
+			user.Tags = []string{}
+		}
+		tags, diags := types.SetValueFrom(ctx, types.StringType, user.Tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ids = append(ids, user.ID)
+		data.LocalUsers = append(data.LocalUsers, LocalUserSummaryModel{
+			ID:        types.StringValue(user.ID),
+			Username:  types.StringValue(user.Username),
+			Email:     types.StringValue(user.Email),
+			FullName:  types.StringValue(user.FullName),
+			Tags:      tags,
+			Principal: types.StringValue(roleStoreUser.Principal),
+			Source:    types.StringValue(roleStoreUser.Source),
+		})
+	}
+
+	var diags diag.Diagnostics
+	data.IDs, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Storing local users into the state", map[string]interface{}{
+		"localUserCount": len(data.LocalUsers),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// defaultProfile is the profile read from the credentials file when only
+// the file is set.
+const defaultProfile = "default"
+
+// credentialsProfile holds the PrivX endpoint settings of a profile of the
+// credentials file.
+type credentialsProfile struct {
+	APIBaseURL        string `toml:"api_base_url" json:"api_base_url"`
+	APIBearerToken    string `toml:"api_bearer_token" json:"api_bearer_token"`
+	APIClientID       string `toml:"api_client_id" json:"api_client_id"`
+	APIClientSecret   string `toml:"api_client_secret" json:"api_client_secret"`
+	OAuthClientID     string `toml:"api_oauth_client_id" json:"api_oauth_client_id"`
+	OAuthClientSecret string `toml:"api_oauth_client_secret" json:"api_oauth_client_secret"`
+	CredentialProcess string `toml:"credential_process" json:"credential_process"`
+}
+
+// defaultCredentialsFile returns the path of the credentials file read when
+// only the profile is set, ~/.privx/credentials.
+func defaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".privx", "credentials"), nil
+}
+
+// loadProfile reads the profile from the credentials file. Files with a
+// .json extension are JSON objects of profiles by name, other files are TOML
+// documents with a table per profile.
+func loadProfile(file, name string) (credentialsProfile, error) {
+	var err error
+	if file == "" {
+		if file, err = defaultCredentialsFile(); err != nil {
+			return credentialsProfile{}, err
+		}
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return credentialsProfile{}, err
+	}
+
+	var profiles map[string]credentialsProfile
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		err = json.Unmarshal(content, &profiles)
+	} else {
+		err = toml.Unmarshal(content, &profiles)
+	}
+	if err != nil {
+		return credentialsProfile{}, fmt.Errorf("unable to parse %s: %w", file, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return credentialsProfile{}, fmt.Errorf("profile %q not found in %s", name, file)
+	}
+	return profile, nil
+}
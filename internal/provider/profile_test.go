@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+
+	tomlFile := filepath.Join(dir, "credentials")
+	if err := os.WriteFile(tomlFile, []byte(`
+[default]
+api_base_url = "https://privx.example.com"
+api_bearer_token = "token"
+
+[staging]
+api_base_url = "https://staging.privx.example.com"
+api_client_id = "client"
+api_client_secret = "secret"
+api_oauth_client_id = "oauth-client"
+api_oauth_client_secret = "oauth-secret"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	jsonFile := filepath.Join(dir, "credentials.json")
+	if err := os.WriteFile(jsonFile, []byte(`{
+  "staging": {
+    "api_base_url": "https://staging.privx.example.com",
+    "api_client_id": "client",
+    "api_client_secret": "secret",
+    "api_oauth_client_id": "oauth-client",
+    "api_oauth_client_secret": "oauth-secret"
+  }
+}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	staging := credentialsProfile{
+		APIBaseURL:        "https://staging.privx.example.com",
+		APIClientID:       "client",
+		APIClientSecret:   "secret",
+		OAuthClientID:     "oauth-client",
+		OAuthClientSecret: "oauth-secret",
+	}
+
+	for _, tc := range []struct {
+		name    string
+		file    string
+		profile string
+		want    credentialsProfile
+		wantErr bool
+	}{
+		{"toml default", tomlFile, "", credentialsProfile{APIBaseURL: "https://privx.example.com", APIBearerToken: "token"}, false},
+		{"toml named", tomlFile, "staging", staging, false},
+		{"json named", jsonFile, "staging", staging, false},
+		{"unknown profile", jsonFile, "production", credentialsProfile{}, true},
+		{"missing file", filepath.Join(dir, "missing"), "staging", credentialsProfile{}, true},
+		{"invalid file", jsonFile + ".toml", "", credentialsProfile{}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.name == "invalid file" {
+				if err := os.WriteFile(tc.file, []byte("{not toml"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := loadProfile(tc.file, tc.profile)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("loadProfile() error = %v, want error %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("loadProfile() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,24 @@
 package provider
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
 	"testing"
 
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/privxmock"
+
+	"github.com/SSHcom/privx-sdk-go/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -16,15 +29,43 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"privx": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccMock is set when the acceptance tests run against the in-memory
+// PrivX API mock.
+var testAccMock bool
+
+// TestMain runs the acceptance tests against an in-memory PrivX API mock,
+// unless PRIVX_API_BASE_URL points the tests to a real PrivX deployment.
+func TestMain(m *testing.M) {
+	if os.Getenv("PRIVX_API_BASE_URL") != "" {
+		os.Exit(m.Run())
+	}
+
+	testAccMock = true
+	server := privxmock.New()
+	for k, v := range map[string]string{
+		"PRIVX_API_BASE_URL":            server.URL,
+		"PRIVX_API_CLIENT_ID":           privxmock.APIClientID,
+		"PRIVX_API_CLIENT_SECRET":       privxmock.APIClientSecret,
+		"PRIVX_API_OAUTH_CLIENT_ID":     privxmock.OAuthClientID,
+		"PRIVX_API_OAUTH_CLIENT_SECRET": privxmock.OAuthClientSecret,
+	} {
+		os.Setenv(k, v)
+	}
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("PRIVX_API_BASE_URL"); v == "" {
 		t.Fatal("PRIVX_API_BASE_URL must be set for acceptance tests")
 	}
-	if v := os.Getenv("PRIVX_OAUTH_CLIENT_ID"); v == "" {
-		t.Fatal("PRIVX_OAUTH_CLIENT_ID must be set for acceptance tests")
+	if v := os.Getenv("PRIVX_API_OAUTH_CLIENT_ID"); v == "" {
+		t.Fatal("PRIVX_API_OAUTH_CLIENT_ID must be set for acceptance tests")
 	}
-	if v := os.Getenv("PRIVX_OAUTH_CLIENT_SECRET"); v == "" {
-		t.Fatal("PRIVX_OAUTH_CLIENT_SECRET must be set for acceptance tests")
+	if v := os.Getenv("PRIVX_API_OAUTH_CLIENT_SECRET"); v == "" {
+		t.Fatal("PRIVX_API_OAUTH_CLIENT_SECRET must be set for acceptance tests")
 	}
 	if v := os.Getenv("PRIVX_API_CLIENT_ID"); v == "" {
 		t.Fatal("PRIVX_API_CLIENT_ID must be set for acceptance tests")
@@ -33,3 +74,280 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("PRIVX_API_CLIENT_SECRET must be set for acceptance tests")
 	}
 }
+
+// testAccConnector returns a connector to the PrivX API the acceptance tests
+// run against.
+func testAccConnector(t *testing.T) restapi.Connector {
+	connector, err := client.NewConnector(
+		os.Getenv("PRIVX_API_BASE_URL"),
+		os.Getenv("PRIVX_API_BEARER_TOKEN"),
+		os.Getenv("PRIVX_API_CLIENT_ID"),
+		os.Getenv("PRIVX_API_CLIENT_SECRET"),
+		os.Getenv("PRIVX_API_OAUTH_CLIENT_ID"),
+		os.Getenv("PRIVX_API_OAUTH_CLIENT_SECRET"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *connector
+}
+
+// testAccCheckDestroy verifies that no resource of the given type is left in
+// PrivX once destroyed. read fetches the object identified by the value of
+// the key attribute in the state.
+func testAccCheckDestroy(t *testing.T, resourceType, key string, read func(connector restapi.Connector, id string) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		connector := testAccConnector(t)
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			err := read(connector, rs.Primary.Attributes[key])
+			if err == nil {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.Attributes[key])
+			}
+			if !client.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccProviderEndpoints(t *testing.T) {
+	if !testAccMock {
+		t.Skip("the other endpoint is an in-memory PrivX API mock, with the credentials of the mock")
+	}
+
+	// The other endpoint inherits the credentials of the provider, which
+	// are those of the mocks
+	other := privxmock.New()
+	defer other.Close()
+
+	name := acctest.RandomWithPrefix("tf-acc")
+	otherConnector := func() restapi.Connector {
+		connector, err := client.NewConnector(other.URL, "", privxmock.APIClientID, privxmock.APIClientSecret, privxmock.OAuthClientID, privxmock.OAuthClientSecret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *connector
+	}
+
+	config := fmt.Sprintf(`
+provider "privx" {
+  endpoints = {
+    other = {
+      api_base_url = %q
+    }
+  }
+}
+
+resource "privx_access_group" "default" {
+  name = %q
+}
+
+resource "privx_access_group" "other" {
+  endpoint = "other"
+  name     = %q
+}
+
+data "privx_access_group" "other" {
+  endpoint = "other"
+  name     = privx_access_group.other.name
+}
+`, other.URL, name+"-default", name+"-other")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_access_group.other", "endpoint", "other"),
+					resource.TestCheckNoResourceAttr("privx_access_group.default", "endpoint"),
+					resource.TestCheckResourceAttrPair("data.privx_access_group.other", "id", "privx_access_group.other", "id"),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources["privx_access_group.other"].Primary.ID
+						if _, err := authorizer.New(otherConnector()).AccessGroup(id); err != nil {
+							return fmt.Errorf("access group %s not found in the other endpoint: %w", id, err)
+						}
+						if _, err := authorizer.New(testAccConnector(t)).AccessGroup(id); !client.IsNotFound(err) {
+							return fmt.Errorf("access group %s found in the default endpoint", id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "privx_access_group.other",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return "other:" + s.RootModule().Resources["privx_access_group.other"].Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccProviderUnknownDefaultEndpoint(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "privx" {
+  default_endpoint = "missing"
+}
+
+data "privx_access_group" "default" {
+  default = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Unknown PrivX endpoint`),
+			},
+		},
+	})
+}
+
+func TestAccProviderProfile(t *testing.T) {
+	testAccPreCheck(t)
+
+	// The profile holds the credentials, which the environment variables
+	// would take precedence over
+	file := filepath.Join(t.TempDir(), "credentials")
+	profile := fmt.Sprintf(`
+[test]
+api_base_url = %q
+api_bearer_token = %q
+api_client_id = %q
+api_client_secret = %q
+api_oauth_client_id = %q
+api_oauth_client_secret = %q
+`,
+		os.Getenv("PRIVX_API_BASE_URL"),
+		os.Getenv("PRIVX_API_BEARER_TOKEN"),
+		os.Getenv("PRIVX_API_CLIENT_ID"),
+		os.Getenv("PRIVX_API_CLIENT_SECRET"),
+		os.Getenv("PRIVX_API_OAUTH_CLIENT_ID"),
+		os.Getenv("PRIVX_API_OAUTH_CLIENT_SECRET"),
+	)
+	if err := os.WriteFile(file, []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, env := range []string{"PRIVX_API_BEARER_TOKEN", "PRIVX_API_CLIENT_ID", "PRIVX_API_CLIENT_SECRET", "PRIVX_API_OAUTH_CLIENT_ID", "PRIVX_API_OAUTH_CLIENT_SECRET"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("PRIVX_CONFIG_FILE", file)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "privx" {
+  profile = "missing"
+}
+
+data "privx_access_group" "default" {
+  default = true
+}
+`,
+				ExpectError: regexp.MustCompile(`profile "missing" not found`),
+			},
+			{
+				Config: `
+provider "privx" {
+  profile = "test"
+}
+
+data "privx_access_group" "default" {
+  default = true
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.privx_access_group.default", "default", "true"),
+			},
+		},
+	})
+}
+
+func TestAccProviderProfileEnvCredentials(t *testing.T) {
+	testAccPreCheck(t)
+
+	// The client credentials of the environment variables take precedence
+	// over the whole profile, whose credential process would fail
+	file := filepath.Join(t.TempDir(), "credentials")
+	profile := `
+[test]
+credential_process = "false"
+`
+	if err := os.WriteFile(file, []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PRIVX_API_BEARER_TOKEN", "")
+	t.Setenv("PRIVX_CONFIG_FILE", file)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "privx" {
+  profile = "test"
+}
+
+data "privx_access_group" "default" {
+  default = true
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.privx_access_group.default", "default", "true"),
+			},
+		},
+	})
+}
+
+func TestAccProviderCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the stub credential process is a shell script")
+	}
+
+	credentials, err := json.Marshal(map[string]string{
+		"bearer_token":        os.Getenv("PRIVX_API_BEARER_TOKEN"),
+		"api_client_id":       os.Getenv("PRIVX_API_CLIENT_ID"),
+		"api_client_secret":   os.Getenv("PRIVX_API_CLIENT_SECRET"),
+		"oauth_client_id":     os.Getenv("PRIVX_API_OAUTH_CLIENT_ID"),
+		"oauth_client_secret": os.Getenv("PRIVX_API_OAUTH_CLIENT_SECRET"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(t.TempDir(), "credential-process")
+	if err := os.WriteFile(script, []byte(fmt.Sprintf("#!/bin/sh\ncat <<'EOF'\n%s\nEOF\n", credentials)), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The credentials of the process replace the ones of the
+				// environment variables
+				Config: fmt.Sprintf(`
+provider "privx" {
+  credential_process = %q
+  api_client_secret  = "wrong"
+}
+
+data "privx_access_group" "default" {
+  default = true
+}
+`, script),
+				Check: resource.TestCheckResourceAttr("data.privx_access_group.default", "default", "true"),
+			},
+		},
+	})
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/rolestore"
 	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -22,7 +24,9 @@ func NewRoleDataSource() datasource.DataSource {
 
 // RoleDataSource defines the data source implementation.
 type RoleDataSource struct {
-	client *rolestore.RoleStore
+	endpoints
+	client    *rolestore.RoleStore
+	connector restapi.Connector
 }
 
 // RoleDataSourceModel describes the data source data model.
@@ -37,6 +41,7 @@ func (d *RoleDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Role data source",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Role ID",
 				Computed:            true,
@@ -69,10 +74,66 @@ func (d *RoleDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 			},
 			"source_rules": schema.StringAttribute{
 				MarkdownDescription: `A source rule(s) definition. Can be a single rule or a rule group, in which case either "single" or "group" attributes are requrired. Defined in JSON`,
+				CustomType:          utils.JSONType{},
 				Computed:            true,
 			},
+			"source_rule": schema.SingleNestedAttribute{
+				MarkdownDescription: "Source rules mapping directory users to the role",
+				Computed:            true,
+				Attributes: roleSourceRuleDataSourceAttributes(map[string]schema.Attribute{
+					"rules": schema.ListNestedAttribute{
+						MarkdownDescription: "Rules of a `GROUP`",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: roleSourceRuleDataSourceAttributes(map[string]schema.Attribute{
+								"rules": schema.ListNestedAttribute{
+									MarkdownDescription: "Rules of a `GROUP`",
+									Computed:            true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: roleSourceRuleDataSourceAttributes(nil),
+									},
+								},
+							}),
+						},
+					},
+				}),
+			},
+		},
+	}
+}
+
+// roleSourceRuleDataSourceAttributes returns the attributes of a source rule
+// along with the given nested ones. match only applies to rules that may be
+// groups.
+func roleSourceRuleDataSourceAttributes(nested map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Source rule type, either `GROUP` or `RULE`",
+			Computed:            true,
+		},
+		"source": schema.StringAttribute{
+			MarkdownDescription: "ID of the source the rule applies to",
+			Computed:            true,
+		},
+		"search_string": schema.StringAttribute{
+			MarkdownDescription: "Search string matched against the source's groups",
+			Computed:            true,
 		},
+		"pattern": schema.StringAttribute{
+			MarkdownDescription: "Regular expression matched against the source's groups",
+			Computed:            true,
+		},
+	}
+	if nested != nil {
+		attributes["match"] = schema.StringAttribute{
+			MarkdownDescription: "Whether `ANY` or `ALL` the rules of a group must match",
+			Computed:            true,
+		}
 	}
+	for name, attribute := range nested {
+		attributes[name] = attribute
+	}
+	return attributes
 }
 
 func (d *RoleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -81,20 +142,22 @@ func (d *RoleDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *RoleDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	d.client = rolestore.New(*connector)
+	d.client = rolestore.New(connector)
+	d.connector = connector
+	return diags
 }
 
 func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -107,6 +170,11 @@ func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// resolve role from role name
 	role_names_as_list := []string{data.Name.ValueString()}
 	roles, err := d.client.ResolveRoles(role_names_as_list)
@@ -121,13 +189,14 @@ func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	// retrieve role from id
-	role, err := d.client.Role(roles[0].ID)
+	role, err := getRole(d.connector, roles[0].ID)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
 		return
 	}
 
+	data.ID = types.StringValue(role.ID)
 	data.Name = types.StringValue(role.Name)
 	data.Comment = types.StringValue(role.Comment)
 	data.AccessGroupID = types.StringValue(role.AccessGroupID)
@@ -154,10 +223,11 @@ func (d *RoleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		)
 		return
 	}
-	data.SourceRule = types.StringValue(string(sourceRuleData))
+	data.SourceRules = utils.NewJSONValue(string(sourceRuleData))
+	data.SourceRule = newRoleSourceRuleModel(role.SourceRule)
 
 	tflog.Debug(ctx, "Storing role type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-privx/internal/client"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleMemberResource{}
+var _ resource.ResourceWithImportState = &RoleMemberResource{}
+var _ resource.ResourceWithValidateConfig = &RoleMemberResource{}
+
+func NewRoleMemberResource() resource.Resource {
+	return &RoleMemberResource{}
+}
+
+// RoleMemberResource defines the resource implementation.
+type RoleMemberResource struct {
+	endpoints
+	connector restapi.Connector
+}
+
+// RoleMemberResourceModel describes the resource data model.
+type RoleMemberResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	RoleID     types.String `tfsdk:"role_id"`
+	UserID     types.String `tfsdk:"user_id"`
+	GrantStart types.String `tfsdk:"grant_start"`
+	GrantEnd   types.String `tfsdk:"grant_end"`
+	Endpoint   types.String `tfsdk:"endpoint"`
+}
+
+func (r *RoleMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_member"
+}
+
+func (r *RoleMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := roleMemberAttributes()
+	attributes["user_id"] = schema.StringAttribute{
+		MarkdownDescription: "ID of the user",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["role_id"] = schema.StringAttribute{
+		MarkdownDescription: "ID of the role",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Membership ID, as `<role_id>/<user_id>`",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["endpoint"] = endpointResourceAttribute()
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role member resource. Grants a role to a user explicitly, leaving the other members of the role untouched.",
+		Attributes:          attributes,
+	}
+}
+
+func (r *RoleMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *RoleMemberResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	r.connector = connector
+	return diags
+}
+
+func (r *RoleMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleMemberResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateGrantWindow(path.Empty(), data.GrantStart, data.GrantEnd)...)
+}
+
+func (data *RoleMemberResourceModel) grant() roleGrant {
+	return roleGrant{
+		UserID:     data.UserID.ValueString(),
+		GrantStart: data.GrantStart.ValueString(),
+		GrantEnd:   data.GrantEnd.ValueString(),
+	}
+}
+
+func (r *RoleMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RoleMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := grantRole(r.connector, data.RoleID.ValueString(), data.grant())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant role, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(data.RoleID.ValueString() + "/" + data.UserID.ValueString())
+
+	tflog.Debug(ctx, "created role member resource", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RoleMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported resources only know their ID
+	roleID, userID, ok := strings.Cut(data.ID.ValueString(), "/")
+	if !ok || roleID == "" || userID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid Role Member ID",
+			fmt.Sprintf("Expected an ID of the form <role_id>/<user_id>, got: %s", data.ID.ValueString()))
+		return
+	}
+	data.RoleID = types.StringValue(roleID)
+	data.UserID = types.StringValue(userID)
+
+	grant, err := getRoleGrant(r.connector, roleID, userID)
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "user not found in PrivX, removing its role membership from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role member, got error: %s", err))
+		return
+	}
+	if grant == nil {
+		tflog.Warn(ctx, "role membership not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	member := newRoleMemberModel(*grant, &RoleMemberModel{
+		UserID:     data.UserID,
+		GrantStart: data.GrantStart,
+		GrantEnd:   data.GrantEnd,
+	})
+	data.GrantStart = member.GrantStart
+	data.GrantEnd = member.GrantEnd
+
+	tflog.Debug(ctx, "Storing role member into the state", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RoleMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := grantRole(r.connector, data.RoleID.ValueString(), data.grant())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update role member, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RoleMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := revokeRole(r.connector, data.RoleID.ValueString(), data.UserID.ValueString())
+	if client.IsNotFound(err) {
+		// The membership went away along with the user
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke role, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoleMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.importState(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-privx/internal/client"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleMembersResource{}
+var _ resource.ResourceWithImportState = &RoleMembersResource{}
+var _ resource.ResourceWithValidateConfig = &RoleMembersResource{}
+
+func NewRoleMembersResource() resource.Resource {
+	return &RoleMembersResource{}
+}
+
+// RoleMembersResource defines the resource implementation.
+type RoleMembersResource struct {
+	endpoints
+	connector restapi.Connector
+}
+
+// RoleMembersResourceModel describes the resource data model.
+type RoleMembersResourceModel struct {
+	ID       types.String      `tfsdk:"id"`
+	RoleID   types.String      `tfsdk:"role_id"`
+	Members  []RoleMemberModel `tfsdk:"members"`
+	Endpoint types.String      `tfsdk:"endpoint"`
+}
+
+func (r *RoleMembersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_members"
+}
+
+func (r *RoleMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role members resource. Authoritative for the explicit members of a role: " +
+			"users granted the role outside of Terraform lose it. Members mapped from the source rules of the role are not affected. " +
+			"Do not use it along with `privx_role_member` resources of the same role.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Role ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": schema.SetNestedAttribute{
+				MarkdownDescription: "Users explicitly granted the role",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: roleMemberAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// roleMemberAttributes returns the attributes of an explicit membership.
+func roleMemberAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"user_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the user",
+			Required:            true,
+		},
+		"grant_start": schema.StringAttribute{
+			MarkdownDescription: "Start of the validity window of a temporary membership, as an RFC 3339 timestamp",
+			Optional:            true,
+		},
+		"grant_end": schema.StringAttribute{
+			MarkdownDescription: "End of the validity window of a temporary membership, as an RFC 3339 timestamp",
+			Optional:            true,
+		},
+	}
+}
+
+func (r *RoleMembersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *RoleMembersResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	r.connector = connector
+	return diags
+}
+
+func (r *RoleMembersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var members types.Set
+
+	// Members that are not known yet are validated once they are
+	diags := req.Config.GetAttribute(ctx, path.Root("members"), &members)
+	if diags.HasError() || members.IsNull() || members.IsUnknown() {
+		return
+	}
+
+	users := map[string]bool{}
+	for _, element := range members.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() {
+			continue
+		}
+		var member RoleMemberModel
+		if diags := object.As(ctx, &member, basetypes.ObjectAsOptions{}); diags.HasError() {
+			continue
+		}
+
+		p := path.Root("members").AtSetValue(element)
+		resp.Diagnostics.Append(validateGrantWindow(p, member.GrantStart, member.GrantEnd)...)
+
+		if member.UserID.IsNull() || member.UserID.IsUnknown() {
+			continue
+		}
+		if users[member.UserID.ValueString()] {
+			resp.Diagnostics.AddAttributeError(p.AtName("user_id"), "Duplicate Role Member",
+				fmt.Sprintf("User %s is a member of the role more than once", member.UserID.ValueString()))
+		}
+		users[member.UserID.ValueString()] = true
+	}
+}
+
+// apply grants the role to the planned members, and revokes it from the
+// other explicit members.
+func (r *RoleMembersResource) apply(ctx context.Context, data *RoleMembersResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	roleID := data.RoleID.ValueString()
+
+	current, err := listRoleGrants(r.connector, roleID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read role members, got error: %s", err))
+		return diags
+	}
+	currentGrants := map[string]roleGrant{}
+	for _, grant := range current {
+		currentGrants[grant.UserID] = grant
+	}
+
+	planned := map[string]bool{}
+	for _, member := range data.Members {
+		grant := member.grant()
+		planned[grant.UserID] = true
+		if currentGrant, ok := currentGrants[grant.UserID]; ok && currentGrant == grant {
+			continue
+		}
+
+		tflog.Debug(ctx, "Granting role", map[string]interface{}{"role_id": roleID, "user_id": grant.UserID})
+		if err := grantRole(r.connector, roleID, grant); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to grant role to user %s, got error: %s", grant.UserID, err))
+			return diags
+		}
+	}
+
+	for _, grant := range current {
+		if planned[grant.UserID] {
+			continue
+		}
+
+		tflog.Debug(ctx, "Revoking role", map[string]interface{}{"role_id": roleID, "user_id": grant.UserID})
+		if err := revokeRole(r.connector, roleID, grant.UserID); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to revoke role from user %s, got error: %s", grant.UserID, err))
+			return diags
+		}
+	}
+	return diags
+}
+
+func (r *RoleMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *RoleMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.RoleID
+
+	tflog.Debug(ctx, "created role members resource", map[string]interface{}{
+		"role_id":     data.RoleID.ValueString(),
+		"memberCount": len(data.Members),
+	})
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *RoleMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported resources only know their ID
+	data.RoleID = data.ID
+
+	grants, err := listRoleGrants(r.connector, data.RoleID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "role not found in PrivX, removing its members from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role members, got error: %s", err))
+		return
+	}
+
+	// Members granted the role outside of Terraform show up as drift
+	prior := map[string]*RoleMemberModel{}
+	for i := range data.Members {
+		prior[data.Members[i].UserID.ValueString()] = &data.Members[i]
+	}
+	members := []RoleMemberModel{}
+	for _, grant := range grants {
+		members = append(members, newRoleMemberModel(grant, prior[grant.UserID]))
+	}
+	data.Members = members
+
+	tflog.Debug(ctx, "Storing role members into the state", map[string]interface{}{
+		"role_id":     data.RoleID.ValueString(),
+		"memberCount": len(data.Members),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *RoleMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *RoleMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := data.RoleID.ValueString()
+	grants, err := listRoleGrants(r.connector, roleID)
+	if client.IsNotFound(err) {
+		// The members of the role went away along with it
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role members, got error: %s", err))
+		return
+	}
+
+	for _, grant := range grants {
+		if err := revokeRole(r.connector, roleID, grant.UserID); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke role from user %s, got error: %s", grant.UserID, err))
+			return
+		}
+	}
+}
+
+func (r *RoleMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	r.importState(ctx, path.Root("id"), req, resp)
+}
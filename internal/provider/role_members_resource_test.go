@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/api/userstore"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccLocalUsers creates local users for the duration of the test, and
+// returns their IDs.
+func testAccLocalUsers(t *testing.T, name string, count int) []string {
+	store := userstore.New(testAccConnector(t))
+
+	ids := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		id, err := store.CreateLocalUser(userstore.LocalUser{
+			Username: fmt.Sprintf("%s-%d", name, i),
+			Password: userstore.Password{Password: acctest.RandString(16)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = store.DeleteLocalUser(id)
+		})
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// testAccCheckRoleID stores the ID of the role in the state into id.
+func testAccCheckRoleID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", resourceName)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckRoleGrant verifies whether the user is an explicit member of
+// the role.
+func testAccCheckRoleGrant(t *testing.T, roleID *string, userID string, granted bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		grant, err := getRoleGrant(testAccConnector(t), *roleID, userID)
+		if err != nil {
+			return err
+		}
+		if (grant != nil) != granted {
+			return fmt.Errorf("expected role granted to user %s to be %t", userID, granted)
+		}
+		return nil
+	}
+}
+
+func TestAccRoleMembersResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+	users := testAccLocalUsers(t, name, 3)
+	var roleID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleMembersResourceConfig(name, users[0], users[1], "2024-02-01T00:00:00Z"),
+				ExpectError: regexp.MustCompile("grant_end must be after grant_start"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoleMembersResourceConfig(name, users[0], users[1], "2030-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRoleID("privx_role.test", &roleID),
+					resource.TestCheckResourceAttrPair("privx_role_members.test", "id", "privx_role.test", "id"),
+					resource.TestCheckResourceAttr("privx_role_members.test", "members.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_role_members.test", "members.*", map[string]string{
+						"user_id":   users[1],
+						"grant_end": "2030-01-01T00:00:00Z",
+					}),
+					testAccCheckRoleGrant(t, &roleID, users[0], true),
+					testAccCheckRoleGrant(t, &roleID, users[1], true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_role_members.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// A member granted the role by hand shows up as drift
+			{
+				PreConfig: func() {
+					if err := rolestore.New(testAccConnector(t)).GrantUserRole(users[2], roleID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccRoleMembersResourceConfig(name, users[0], users[1], "2030-01-01T00:00:00Z"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// and is revoked the role
+			{
+				Config: testAccRoleMembersResourceConfig(name, users[0], users[1], "2030-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_role_members.test", "members.#", "2"),
+					testAccCheckRoleGrant(t, &roleID, users[2], false),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoleMembersResourceConfig(name, users[2], users[1], "2031-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_role_members.test", "members.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("privx_role_members.test", "members.*", map[string]string{
+						"user_id":   users[1],
+						"grant_end": "2031-01-01T00:00:00Z",
+					}),
+					testAccCheckRoleGrant(t, &roleID, users[0], false),
+					testAccCheckRoleGrant(t, &roleID, users[2], true),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			for _, user := range users {
+				if err := testAccCheckRoleGrant(t, &roleID, user, false)(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccRoleMemberResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+	users := testAccLocalUsers(t, name, 2)
+	var roleID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRoleMemberResourceConfig(name, users[0], "tomorrow", users[1]),
+				ExpectError: regexp.MustCompile("grant_end must be an RFC 3339 timestamp"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccRoleMemberResourceConfig(name, users[0], "2030-01-01T00:00:00Z", users[1]),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckRoleID("privx_role.test", &roleID),
+					resource.TestCheckResourceAttr("privx_role_member.test", "user_id", users[0]),
+					resource.TestCheckResourceAttr("privx_role_member.test", "grant_end", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("privx_role_member.explicit", "user_id", users[1]),
+					resource.TestCheckNoResourceAttr("privx_role_member.explicit", "grant_start"),
+					testAccCheckRoleGrant(t, &roleID, users[0], true),
+					testAccCheckRoleGrant(t, &roleID, users[1], true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_role_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoleMemberResourceConfig(name, users[0], "2031-01-01T00:00:00Z", users[1]),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_role_member.test", "grant_end", "2031-01-01T00:00:00Z"),
+					testAccCheckRoleGrant(t, &roleID, users[1], true),
+				),
+			},
+			// A membership revoked by hand is granted again
+			{
+				PreConfig: func() {
+					if err := rolestore.New(testAccConnector(t)).RevokeUserRole(users[1], roleID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccRoleMemberResourceConfig(name, users[0], "2031-01-01T00:00:00Z", users[1]),
+				Check:  testAccCheckRoleGrant(t, &roleID, users[1], true),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRoleMembersResourceConfig(name, member, temporaryMember, grantEnd string) string {
+	return testAccRoleResourceConfig(name, "comment", `"users-view"`) + fmt.Sprintf(`
+resource "privx_role_members" "test" {
+  role_id = privx_role.test.id
+
+  members = [
+    {
+      user_id = %[1]q
+    },
+    {
+      user_id     = %[2]q
+      grant_start = "2024-03-01T00:00:00Z"
+      grant_end   = %[3]q
+    },
+  ]
+}
+`, member, temporaryMember, grantEnd)
+}
+
+func testAccRoleMemberResourceConfig(name, user, grantEnd, explicitUser string) string {
+	return testAccRoleResourceConfig(name, "comment", `"users-view"`) + fmt.Sprintf(`
+resource "privx_role_member" "test" {
+  role_id     = privx_role.test.id
+  user_id     = %[1]q
+  grant_start = "2024-03-01T00:00:00Z"
+  grant_end   = %[2]q
+}
+
+resource "privx_role_member" "explicit" {
+  role_id = privx_role.test.id
+  user_id = %[3]q
+}
+`, user, grantEnd, explicitUser)
+}
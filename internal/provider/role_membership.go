@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Grant types of the explicit role memberships. Memberships with a validity
+// window are temporary, the others are explicit.
+const (
+	grantTypePermanent      = "PERMANENT"
+	grantTypeTimeRestricted = "TIME_RESTRICTED"
+)
+
+// roleGrantsMu serializes the updates of the roles of users. PrivX replaces
+// all the roles of a user at once, so concurrent memberships of a user would
+// otherwise overwrite each other.
+var roleGrantsMu sync.Mutex
+
+type (
+	// roleGrant is the explicit membership of a user to a role.
+	roleGrant struct {
+		UserID     string
+		GrantStart string
+		GrantEnd   string
+	}
+
+	// RoleMemberModel is a member of the privx_role_members resource.
+	RoleMemberModel struct {
+		UserID     types.String `tfsdk:"user_id"`
+		GrantStart types.String `tfsdk:"grant_start"`
+		GrantEnd   types.String `tfsdk:"grant_end"`
+	}
+)
+
+// grant returns the membership of the member.
+func (m RoleMemberModel) grant() roleGrant {
+	return roleGrant{
+		UserID:     m.UserID.ValueString(),
+		GrantStart: m.GrantStart.ValueString(),
+		GrantEnd:   m.GrantEnd.ValueString(),
+	}
+}
+
+// newRoleMemberModel returns the member of the membership. The validity
+// window keeps the representation of the prior member when PrivX returns
+// the same instants in another format.
+func newRoleMemberModel(grant roleGrant, prior *RoleMemberModel) RoleMemberModel {
+	if prior == nil {
+		prior = &RoleMemberModel{}
+	}
+	return RoleMemberModel{
+		UserID:     types.StringValue(grant.UserID),
+		GrantStart: grantTime(prior.GrantStart, grant.GrantStart),
+		GrantEnd:   grantTime(prior.GrantEnd, grant.GrantEnd),
+	}
+}
+
+func grantTime(prior types.String, value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.StringValue(value)
+	}
+
+	priorTime, err := time.Parse(time.RFC3339, prior.ValueString())
+	if err != nil {
+		return types.StringValue(value)
+	}
+	valueTime, err := time.Parse(time.RFC3339, value)
+	if err != nil || !valueTime.Equal(priorTime) {
+		return types.StringValue(value)
+	}
+	return prior
+}
+
+// validateGrantWindow checks the validity window of the membership at p.
+// Bounds that are not known yet are validated once they are.
+func validateGrantWindow(p path.Path, start, end types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	parse := func(name string, value types.String) *time.Time {
+		if value.IsNull() || value.IsUnknown() {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(p.AtName(name), "Invalid Grant Window",
+				fmt.Sprintf("%s must be an RFC 3339 timestamp, such as 2024-01-31T08:00:00Z, got: %s", name, value.ValueString()))
+			return nil
+		}
+		return &t
+	}
+
+	startTime := parse("grant_start", start)
+	endTime := parse("grant_end", end)
+	if startTime != nil && endTime != nil && !endTime.After(*startTime) {
+		diags.AddAttributeError(p.AtName("grant_end"), "Invalid Grant Window",
+			"grant_end must be after grant_start")
+	}
+	return diags
+}
+
+// listRoleMembers returns all the members of the role, whether explicit or
+// mapped from the source rules of the role.
+func listRoleMembers(connector restapi.Connector, roleID string) ([]rolestore.User, error) {
+	const pageSize = 100
+
+	var members []rolestore.User
+	for offset := 0; ; offset += pageSize {
+		page, err := rolestore.New(connector).GetRoleMembers(roleID, offset, pageSize, "", "")
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if len(page) < pageSize {
+			return members, nil
+		}
+	}
+}
+
+// listRoleGrants returns the explicit memberships of the role.
+func listRoleGrants(connector restapi.Connector, roleID string) ([]roleGrant, error) {
+	members, err := listRoleMembers(connector, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := []roleGrant{}
+	for _, member := range members {
+		grant, err := getRoleGrant(connector, roleID, member.ID)
+		if err != nil {
+			return nil, err
+		}
+		if grant != nil {
+			grants = append(grants, *grant)
+		}
+	}
+	return grants, nil
+}
+
+// getRoleGrant returns the explicit membership of the user to the role, nil
+// when the user is not an explicit member of the role.
+func getRoleGrant(connector restapi.Connector, roleID, userID string) (*roleGrant, error) {
+	roles, err := rolestore.New(connector).UserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if role.ID == roleID && role.Explicit {
+			return &roleGrant{
+				UserID:     userID,
+				GrantStart: role.GrantStart,
+				GrantEnd:   role.GrantEnd,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// grantRole makes the user an explicit member of the role, replacing the
+// validity window of an existing membership.
+func grantRole(connector restapi.Connector, roleID string, grant roleGrant) error {
+	roleGrantsMu.Lock()
+	defer roleGrantsMu.Unlock()
+
+	roles, err := rolestore.New(connector).UserRoles(grant.UserID)
+	if err != nil {
+		return err
+	}
+
+	role := rolestore.Role{
+		ID:         roleID,
+		Explicit:   true,
+		GrantType:  grantTypePermanent,
+		GrantStart: grant.GrantStart,
+		GrantEnd:   grant.GrantEnd,
+	}
+	if grant.GrantStart != "" || grant.GrantEnd != "" {
+		role.GrantType = grantTypeTimeRestricted
+	}
+
+	granted := false
+	for i := range roles {
+		if roles[i].ID == roleID {
+			roles[i] = role
+			granted = true
+		}
+	}
+	if !granted {
+		roles = append(roles, role)
+	}
+
+	return setUserRoles(connector, grant.UserID, roles)
+}
+
+// revokeRole removes the explicit membership of the user to the role. Users
+// that are not explicit members of the role are left untouched.
+func revokeRole(connector restapi.Connector, roleID, userID string) error {
+	roleGrantsMu.Lock()
+	defer roleGrantsMu.Unlock()
+
+	roles, err := rolestore.New(connector).UserRoles(userID)
+	if err != nil {
+		return err
+	}
+
+	kept := []rolestore.Role{}
+	for _, role := range roles {
+		if role.ID != roleID || !role.Explicit {
+			kept = append(kept, role)
+		}
+	}
+	if len(kept) == len(roles) {
+		return nil
+	}
+
+	return setUserRoles(connector, userID, kept)
+}
+
+func setUserRoles(connector restapi.Connector, userID string, roles []rolestore.Role) error {
+	_, err := connector.
+		URL("/role-store/api/v1/users/%s/roles", url.PathEscape(userID)).
+		Put(roles)
+
+	return err
+}
@@ -5,18 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"terraform-provider-privx/internal/client"
 	"terraform-provider-privx/internal/utils"
 	"time"
 
 	"github.com/SSHcom/privx-sdk-go/api/rolestore"
 	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -28,6 +33,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RoleResource{}
 var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithConfigValidators = &RoleResource{}
+var _ resource.ResourceWithValidateConfig = &RoleResource{}
 
 func NewRoleResource() resource.Resource {
 	return &RoleResource{}
@@ -35,19 +42,23 @@ func NewRoleResource() resource.Resource {
 
 // RoleResource defines the resource implementation.
 type RoleResource struct {
-	client *rolestore.RoleStore
+	endpoints
+	client    *rolestore.RoleStore
+	connector restapi.Connector
 }
 
 // Role contains PrivX role information.
 type RoleResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Comment       types.String `tfsdk:"comment"`
-	AccessGroupID types.String `tfsdk:"access_group_id"`
-	Permissions   types.Set    `tfsdk:"permissions"`
-	PublicKey     types.Set    `tfsdk:"principal_public_key_strings"`
-	PermitAgent   types.Bool   `tfsdk:"permit_agent"`
-	SourceRule    types.String `tfsdk:"source_rules"`
+	ID            types.String         `tfsdk:"id"`
+	Name          types.String         `tfsdk:"name"`
+	Comment       types.String         `tfsdk:"comment"`
+	AccessGroupID types.String         `tfsdk:"access_group_id"`
+	Permissions   types.Set            `tfsdk:"permissions"`
+	PublicKey     types.Set            `tfsdk:"principal_public_key_strings"`
+	PermitAgent   types.Bool           `tfsdk:"permit_agent"`
+	SourceRules   utils.JSON           `tfsdk:"source_rules"`
+	SourceRule    *RoleSourceRuleModel `tfsdk:"source_rule"`
+	Endpoint      types.String         `tfsdk:"endpoint"`
 }
 
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,6 +70,7 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Role resource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Role ID",
 				Computed:            true,
@@ -77,11 +89,15 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"comment": schema.StringAttribute{
 				MarkdownDescription: "A comment describing the object",
 				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
 			},
 			"permissions": schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "Role permissions",
 				Optional:            true,
+				Computed:            true,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
 				Validators: []validator.Set{
 					setvalidator.ValueStringsAre(
 						stringvalidator.OneOf(
@@ -145,12 +161,16 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Default:             booldefault.StaticBool(false),
 			},
 			"source_rules": schema.StringAttribute{
-				MarkdownDescription: `A source rule(s) definition. Can be a single rule or a rule group, in which case either "single" or "group" attributes are requrired. Defined in JSON`,
+				MarkdownDescription: `A source rule(s) definition. Can be a single rule or a rule group, in which case either "single" or "group" attributes are requrired. Defined in JSON. Conflicts with ` + "`source_rule`",
+				DeprecationMessage:  "Use the source_rule block instead.",
+				CustomType:          utils.JSONType{},
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString(`{"type":"GROUP","match":"ANY","rules":[]}`),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"source_rule": roleSourceRuleBlock(),
+		},
 	}
 }
 
@@ -160,20 +180,81 @@ func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *RoleResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = rolestore.New(*connector)
+	r.client = rolestore.New(connector)
+	r.connector = connector
+	return diags
+}
+
+func (r *RoleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("source_rule"),
+			path.MatchRoot("source_rules"),
+		),
+	}
+}
+
+func (r *RoleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sourceRule *RoleSourceRuleModel
+
+	// Rules that are not known yet are validated once they are
+	diags := req.Config.GetAttribute(ctx, path.Root("source_rule"), &sourceRule)
+	if diags.HasError() || sourceRule == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(sourceRule.validate(path.Root("source_rule"))...)
+}
+
+// sourceRulePayload returns the source rules planned in either the
+// source_rule or the deprecated source_rules attribute.
+func (data *RoleResourceModel) sourceRulePayload() (roleSourceRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if data.SourceRule != nil {
+		return data.SourceRule.payload(), diags
+	}
+
+	sourceRule := defaultRoleSourceRule()
+	if data.SourceRules.IsNull() || data.SourceRules.IsUnknown() {
+		return sourceRule, diags
+	}
+	if err := json.Unmarshal([]byte(data.SourceRules.ValueString()), &sourceRule); err != nil {
+		diags.AddAttributeError(path.Root("source_rules"), "Invalid Source Rules", "Cannot unmarshal source_rules JSON.\n"+err.Error())
+	}
+	return sourceRule, diags
+}
+
+// setSourceRules sets the source rule attributes that may not be known until
+// applied, from the source rules sent to PrivX.
+func (data *RoleResourceModel) setSourceRules(sourceRule roleSourceRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if data.SourceRules.IsNull() || data.SourceRules.IsUnknown() {
+		sourceRuleData, err := json.Marshal(sourceRule)
+		if err != nil {
+			diags.AddError("Unable to store Resource", "Cannot marshal SourceRule data to json.\n"+err.Error())
+			return diags
+		}
+		data.SourceRules = utils.NewJSONValue(string(sourceRuleData))
+	}
+	if data.SourceRule != nil {
+		data.SourceRule = newRoleSourceRuleModel(sourceRule)
+	}
+	return diags
 }
 
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -186,8 +267,13 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Loaded role type data", map[string]interface{}{
-		"data": fmt.Sprintf("%+v", data),
+		"data": utils.Redacted(data),
 	})
 
 	if !data.AccessGroupID.IsUnknown() && data.AccessGroupID.ValueString() == "" {
@@ -203,29 +289,26 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		}
 	}
 
-	var sourceRule rolestore.SourceRule
-	err := json.Unmarshal([]byte(data.SourceRule.ValueString()), &sourceRule)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Cannot unmarshal sourceRule to json.\n"+
-				err.Error(),
-		)
+	sourceRule, diags := data.sourceRulePayload()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	role := rolestore.Role{
-		Name:          data.Name.ValueString(),
-		Comment:       data.Comment.ValueString(),
-		AccessGroupID: data.AccessGroupID.ValueString(),
-		Permissions:   permissionsPayload,
-		PermitAgent:   data.PermitAgent.ValueBool(),
-		SourceRule:    sourceRule,
+	role := roleWithSourceRule{
+		Role: rolestore.Role{
+			Name:          data.Name.ValueString(),
+			Comment:       data.Comment.ValueString(),
+			AccessGroupID: data.AccessGroupID.ValueString(),
+			Permissions:   permissionsPayload,
+			PermitAgent:   data.PermitAgent.ValueBool(),
+		},
+		SourceRule: sourceRule,
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("rolestore.Role model used: %+v", role))
+	tflog.Debug(ctx, "rolestore.Role model used: "+utils.Redacted(role))
 
-	roleID, err := r.client.CreateRole(role)
+	roleID, err := createRole(r.connector, &role)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create the role, got error: %s", err))
 		return
@@ -265,6 +348,11 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	data.PublicKey = publicKey
 	data.ID = types.StringValue(roleID)
 
+	resp.Diagnostics.Append(data.setSourceRules(sourceRule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Resource",
@@ -289,7 +377,17 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	role, err := r.client.Role(data.ID.ValueString())
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := getRole(r.connector, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "role not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
 		return
@@ -300,6 +398,9 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.AccessGroupID = types.StringValue(role.AccessGroupID)
 	data.PermitAgent = types.BoolValue(role.PermitAgent)
 
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
 	permissions, diags := types.SetValueFrom(ctx, data.Permissions.ElementType(ctx), role.Permissions)
 	if diags.HasError() {
 		return
@@ -322,13 +423,14 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	equal, _ := utils.JSONBytesEqual(sourceRuleData, []byte(data.SourceRule.ValueString()))
-	if !equal {
-		data.SourceRule = types.StringValue(string(sourceRuleData))
+	data.SourceRules = utils.NewJSONValue(string(sourceRuleData))
+	// source_rule is only tracked when configured, source_rules otherwise
+	if data.SourceRule != nil {
+		data.SourceRule = newRoleSourceRuleModel(role.SourceRule)
 	}
 
 	tflog.Debug(ctx, "Storing role type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -344,6 +446,11 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var permissionsPayload []string
 	if len(data.Permissions.Elements()) > 0 {
 		resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissionsPayload, false)...)
@@ -352,14 +459,9 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 	}
 
-	var sourceRule rolestore.SourceRule
-	err := json.Unmarshal([]byte(data.SourceRule.ValueString()), &sourceRule)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Cannot unmarshal json.\n"+
-				err.Error(),
-		)
+	sourceRule, diags := data.sourceRulePayload()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -371,20 +473,22 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 	}
 
-	role := rolestore.Role{
-		ID:            data.ID.ValueString(),
-		Name:          data.Name.ValueString(),
-		Comment:       data.Comment.ValueString(),
-		AccessGroupID: data.AccessGroupID.ValueString(),
-		Permissions:   permissionsPayload,
-		PermitAgent:   data.PermitAgent.ValueBool(),
-		PublicKey:     publicKeyPayload,
-		SourceRule:    sourceRule,
+	role := roleWithSourceRule{
+		Role: rolestore.Role{
+			ID:            data.ID.ValueString(),
+			Name:          data.Name.ValueString(),
+			Comment:       data.Comment.ValueString(),
+			AccessGroupID: data.AccessGroupID.ValueString(),
+			Permissions:   permissionsPayload,
+			PermitAgent:   data.PermitAgent.ValueBool(),
+			PublicKey:     publicKeyPayload,
+		},
+		SourceRule: sourceRule,
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("rolestore.Role model used: %+v", role))
+	tflog.Debug(ctx, "rolestore.Role model used: "+utils.Redacted(role))
 
-	err = r.client.UpdateRole(
+	err := updateRole(r.connector,
 		data.ID.ValueString(),
 		&role)
 	if err != nil {
@@ -392,6 +496,11 @@ func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(data.setSourceRules(sourceRule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -406,6 +515,11 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteRole(data.ID.ValueString())
 
 	if err != nil {
@@ -415,5 +529,5 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 }
 
 func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.importState(ctx, path.Root("id"), req, resp)
 }
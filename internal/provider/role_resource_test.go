@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoleResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_role", "id", func(connector restapi.Connector, id string) error {
+			_, err := rolestore.New(connector).Role(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleResourceConfig(name, "first comment", `"users-view"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_role.test", "name", name),
+					resource.TestCheckResourceAttr("privx_role.test", "comment", "first comment"),
+					resource.TestCheckResourceAttr("privx_role.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("privx_role.test", "principal_public_key_strings.#", "1"),
+					resource.TestCheckResourceAttrPair("privx_role.test", "access_group_id", "privx_access_group.test", "id"),
+					resource.TestCheckResourceAttrSet("privx_role.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "privx_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoleResourceConfig(name, "second comment", `"users-view", "users-manage"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_role.test", "comment", "second comment"),
+					resource.TestCheckResourceAttr("privx_role.test", "permissions.#", "2"),
+					resource.TestCheckResourceAttr("privx_role.test", "principal_public_key_strings.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoleResourceSourceRule(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRoleResourceSourceRuleConfig(name, "ANY", "CN=admins,DC=example,DC=com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.type", "GROUP"),
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.match", "ANY"),
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.rules.#", "2"),
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.rules.0.search_string", "CN=admins,DC=example,DC=com"),
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.rules.1.rules.#", "2"),
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.rules.1.rules.1.pattern", "^ops-.*"),
+					resource.TestCheckResourceAttrSet("privx_role.test", "source_rules"),
+				),
+			},
+			// ImportState testing, the source_rule block is only tracked when configured
+			{
+				ResourceName:            "privx_role.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source_rule"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccRoleResourceSourceRuleConfig(name, "ALL", "CN=operators,DC=example,DC=com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.match", "ALL"),
+					resource.TestCheckResourceAttr("privx_role.test", "source_rule.rules.0.search_string", "CN=operators,DC=example,DC=com"),
+				),
+			},
+			// Switching to the deprecated JSON form
+			{
+				Config: testAccRoleResourceSourceRulesConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("privx_role.test", "source_rule.type"),
+					resource.TestMatchResourceAttr("privx_role.test", "source_rules", regexp.MustCompile(`"type":"RULE"`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRoleResourceSourceRuleValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "privx_role" "test" {
+  name            = "invalid"
+  access_group_id = "00000000-0000-4000-8000-000000000000"
+
+  source_rule {
+    type = "GROUP"
+
+    rules {
+      type          = "RULE"
+      search_string = "CN=admins,DC=example,DC=com"
+    }
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("source is required on a RULE source rule"),
+			},
+			{
+				Config: `
+resource "privx_role" "test" {
+  name            = "invalid"
+  access_group_id = "00000000-0000-4000-8000-000000000000"
+
+  source_rule {
+    type  = "GROUP"
+    match = "SOME"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: `
+resource "privx_role" "test" {
+  name            = "invalid"
+  access_group_id = "00000000-0000-4000-8000-000000000000"
+  source_rules    = jsonencode({ type = "GROUP", match = "ANY", rules = [] })
+
+  source_rule {
+    type = "GROUP"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config: `
+resource "privx_role" "test" {
+  name            = "invalid"
+  access_group_id = "00000000-0000-4000-8000-000000000000"
+  source_rules    = "{\"type\": \"GROUP\","
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid JSON Value"),
+			},
+		},
+	})
+}
+
+func TestAccRoleDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleResourceConfig(name, "comment", `"users-view"`) + `
+data "privx_role" "test" {
+  name = privx_role.test.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.privx_role.test", "id", "privx_role.test", "id"),
+					resource.TestCheckResourceAttrPair("data.privx_role.test", "access_group_id", "privx_role.test", "access_group_id"),
+					resource.TestCheckResourceAttr("data.privx_role.test", "comment", "comment"),
+					resource.TestCheckResourceAttr("data.privx_role.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.privx_role.test", "principal_public_key_strings.#", "1"),
+					resource.TestCheckResourceAttr("data.privx_role.test", "source_rule.type", "GROUP"),
+					resource.TestCheckResourceAttr("data.privx_role.test", "source_rule.rules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRolesDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleResourceSourceRuleConfig(name, "ANY", "CN=admins,DC=example,DC=com") + fmt.Sprintf(`
+data "privx_access_group" "default" {
+  default = true
+}
+
+resource "privx_role" "other" {
+  name            = %[1]q
+  access_group_id = data.privx_access_group.default.id
+  permissions     = ["users-view", "users-manage"]
+}
+
+data "privx_roles" "prefixed" {
+  name_regex = "^${privx_role.test.name}"
+  depends_on = [privx_role.other]
+}
+
+data "privx_roles" "permission" {
+  name_regex = "^${privx_role.test.name}"
+  permission = "users-manage"
+  depends_on = [privx_role.other]
+}
+
+data "privx_roles" "access_group" {
+  access_group_id = privx_access_group.test.id
+  depends_on      = [privx_role.test, privx_role.other]
+}
+
+data "privx_roles" "source" {
+  source     = privx_source.test.id
+  depends_on = [privx_role.test, privx_role.other]
+}
+`, name+"-other"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_roles.prefixed", "roles.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("data.privx_roles.prefixed", "ids.*", "privx_role.test", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.privx_roles.prefixed", "ids.*", "privx_role.other", "id"),
+					resource.TestCheckResourceAttr("data.privx_roles.permission", "roles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_roles.permission", "roles.0.id", "privx_role.other", "id"),
+					resource.TestCheckResourceAttr("data.privx_roles.permission", "names.0", name+"-other"),
+					resource.TestCheckResourceAttr("data.privx_roles.permission", "roles.0.permissions.#", "2"),
+					resource.TestCheckResourceAttr("data.privx_roles.permission", "roles.0.principal_public_key_strings.#", "1"),
+					resource.TestCheckResourceAttr("data.privx_roles.access_group", "roles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_roles.access_group", "roles.0.id", "privx_role.test", "id"),
+					resource.TestCheckResourceAttr("data.privx_roles.source", "roles.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_roles.source", "ids.0", "privx_role.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRoleResourceConfig(name, comment, permissions string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_role" "test" {
+  name            = %[1]q
+  comment         = %[2]q
+  access_group_id = privx_access_group.test.id
+  permissions     = [%[3]s]
+}
+`, name, comment, permissions)
+}
+
+func testAccRoleResourceSourceRuleConfig(name, match, searchString string) string {
+	return testAccSourceResourceConfig(name, "comment", "Login") + fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_role" "test" {
+  name            = %[1]q
+  access_group_id = privx_access_group.test.id
+
+  source_rule {
+    type  = "GROUP"
+    match = %[2]q
+
+    rules {
+      type          = "RULE"
+      source        = privx_source.test.id
+      search_string = %[3]q
+    }
+
+    rules {
+      type  = "GROUP"
+      match = "ALL"
+
+      rules {
+        type          = "RULE"
+        source        = privx_source.test.id
+        search_string = "CN=users,DC=example,DC=com"
+      }
+
+      rules {
+        type    = "RULE"
+        source  = privx_source.test.id
+        pattern = "^ops-.*"
+      }
+    }
+  }
+}
+`, name, match, searchString)
+}
+
+func testAccRoleResourceSourceRulesConfig(name string) string {
+	return testAccSourceResourceConfig(name, "comment", "Login") + fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_role" "test" {
+  name            = %[1]q
+  access_group_id = privx_access_group.test.id
+  source_rules = jsonencode({
+    type          = "RULE"
+    match         = "ANY"
+    source        = privx_source.test.id
+    search_string = "CN=admins,DC=example,DC=com"
+    rules         = []
+  })
+}
+`, name)
+}
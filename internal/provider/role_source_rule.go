@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	sourceRuleGroup = "GROUP"
+	sourceRuleRule  = "RULE"
+)
+
+type (
+	// roleSourceRule is the PrivX source rule definition. Unlike
+	// rolestore.SourceRule it carries both search_string and pattern.
+	roleSourceRule struct {
+		Type         string           `json:"type"`
+		Match        string           `json:"match,omitempty"`
+		Source       string           `json:"source,omitempty"`
+		SearchString string           `json:"search_string,omitempty"`
+		Pattern      string           `json:"pattern,omitempty"`
+		Rules        []roleSourceRule `json:"rules"`
+	}
+
+	// roleWithSourceRule is a rolestore.Role with its complete source rules.
+	roleWithSourceRule struct {
+		rolestore.Role
+		SourceRule roleSourceRule `json:"source_rules"`
+	}
+
+	// RoleSourceRuleModel is the top level source rule of a role.
+	RoleSourceRuleModel struct {
+		Type         types.String             `tfsdk:"type"`
+		Match        types.String             `tfsdk:"match"`
+		Source       types.String             `tfsdk:"source"`
+		SearchString types.String             `tfsdk:"search_string"`
+		Pattern      types.String             `tfsdk:"pattern"`
+		Rules        []RoleSourceSubRuleModel `tfsdk:"rules"`
+	}
+
+	// RoleSourceSubRuleModel is a rule, or a group of rules, of a top level
+	// source rule group.
+	RoleSourceSubRuleModel struct {
+		Type         types.String              `tfsdk:"type"`
+		Match        types.String              `tfsdk:"match"`
+		Source       types.String              `tfsdk:"source"`
+		SearchString types.String              `tfsdk:"search_string"`
+		Pattern      types.String              `tfsdk:"pattern"`
+		Rules        []RoleSourceLeafRuleModel `tfsdk:"rules"`
+	}
+
+	// RoleSourceLeafRuleModel is a rule of a nested source rule group, which
+	// does not need to define how its rules match.
+	RoleSourceLeafRuleModel struct {
+		Type         types.String `tfsdk:"type"`
+		Source       types.String `tfsdk:"source"`
+		SearchString types.String `tfsdk:"search_string"`
+		Pattern      types.String `tfsdk:"pattern"`
+	}
+)
+
+// defaultRoleSourceRule is the source rule of a role that is not mapped to
+// any directory.
+func defaultRoleSourceRule() roleSourceRule {
+	return roleSourceRule{Type: sourceRuleGroup, Match: "ANY", Rules: []roleSourceRule{}}
+}
+
+// sourceRuleAttributes returns the attributes of a source rule. Rules of a
+// nested group can only be of type RULE. type is required, but as attributes
+// of an absent block are still checked by the framework, this is enforced by
+// validateSourceRule.
+func sourceRuleAttributes(ruleTypes ...string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Source rule type, one of `" + strings.Join(ruleTypes, "`, `") + "`. Required",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(ruleTypes...),
+			},
+		},
+		"match": schema.StringAttribute{
+			MarkdownDescription: "Whether `ANY` or `ALL` the rules of a group must match (Defaults to `ANY`)",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("ANY", "ALL"),
+			},
+		},
+		"source": schema.StringAttribute{
+			MarkdownDescription: "ID of the source the rule applies to. Required for `RULE` rules",
+			Optional:            true,
+		},
+		"search_string": schema.StringAttribute{
+			MarkdownDescription: "Search string matched against the source's groups, for example an LDAP group DN",
+			Optional:            true,
+		},
+		"pattern": schema.StringAttribute{
+			MarkdownDescription: "Regular expression matched against the source's groups",
+			Optional:            true,
+		},
+	}
+}
+
+// roleSourceRuleBlock returns the schema of the source_rule block. PrivX
+// source rules nest up to a group of groups of rules.
+func roleSourceRuleBlock() schema.SingleNestedBlock {
+	leaf := sourceRuleAttributes(sourceRuleRule)
+	delete(leaf, "match")
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Source rules mapping directory users to the role. Either a single `RULE`, or a `GROUP` of rules and groups of rules. Conflicts with `source_rules`",
+		Attributes:          sourceRuleAttributes(sourceRuleGroup, sourceRuleRule),
+		Blocks: map[string]schema.Block{
+			"rules": schema.ListNestedBlock{
+				MarkdownDescription: "Rules of a `GROUP`",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: sourceRuleAttributes(sourceRuleGroup, sourceRuleRule),
+					Blocks: map[string]schema.Block{
+						"rules": schema.ListNestedBlock{
+							MarkdownDescription: "Rules of a nested `GROUP`",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: leaf,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateSourceRule checks that a source rule is consistent with its type.
+// Values that are not known yet are left to the server.
+func validateSourceRule(p path.Path, ruleType, source, searchString, pattern types.String, rules int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch {
+	case ruleType.IsNull():
+		diags.AddAttributeError(p.AtName("type"), "Invalid Source Rule", "type is required on source rules")
+	case ruleType.ValueString() == sourceRuleGroup:
+		matching := []struct {
+			name  string
+			value types.String
+		}{
+			{"source", source},
+			{"search_string", searchString},
+			{"pattern", pattern},
+		}
+		for _, a := range matching {
+			if a.value.ValueString() != "" {
+				diags.AddAttributeError(p.AtName(a.name), "Invalid Source Rule",
+					fmt.Sprintf("%s cannot be set on a GROUP source rule", a.name))
+			}
+		}
+	case ruleType.ValueString() == sourceRuleRule:
+		if !source.IsUnknown() && source.ValueString() == "" {
+			diags.AddAttributeError(p.AtName("source"), "Invalid Source Rule", "source is required on a RULE source rule")
+		}
+		if rules > 0 {
+			diags.AddAttributeError(p.AtName("rules"), "Invalid Source Rule", "rules can only be set on a GROUP source rule")
+		}
+	}
+	return diags
+}
+
+func (m *RoleSourceRuleModel) validate(p path.Path) diag.Diagnostics {
+	diags := validateSourceRule(p, m.Type, m.Source, m.SearchString, m.Pattern, len(m.Rules))
+	for i, rule := range m.Rules {
+		diags.Append(rule.validate(p.AtName("rules").AtListIndex(i))...)
+	}
+	return diags
+}
+
+func (m *RoleSourceSubRuleModel) validate(p path.Path) diag.Diagnostics {
+	diags := validateSourceRule(p, m.Type, m.Source, m.SearchString, m.Pattern, len(m.Rules))
+	for i, rule := range m.Rules {
+		diags.Append(validateSourceRule(p.AtName("rules").AtListIndex(i), rule.Type, rule.Source, rule.SearchString, rule.Pattern, 0)...)
+	}
+	return diags
+}
+
+// sourceRuleMatch defaults match to ANY, as PrivX does.
+func sourceRuleMatch(match types.String) string {
+	if match.ValueString() == "" {
+		return "ANY"
+	}
+	return match.ValueString()
+}
+
+func (m *RoleSourceRuleModel) payload() roleSourceRule {
+	rule := roleSourceRule{
+		Type:         m.Type.ValueString(),
+		Match:        sourceRuleMatch(m.Match),
+		Source:       m.Source.ValueString(),
+		SearchString: m.SearchString.ValueString(),
+		Pattern:      m.Pattern.ValueString(),
+		Rules:        []roleSourceRule{},
+	}
+	for _, sub := range m.Rules {
+		subRule := roleSourceRule{
+			Type:         sub.Type.ValueString(),
+			Match:        sourceRuleMatch(sub.Match),
+			Source:       sub.Source.ValueString(),
+			SearchString: sub.SearchString.ValueString(),
+			Pattern:      sub.Pattern.ValueString(),
+			Rules:        []roleSourceRule{},
+		}
+		for _, leaf := range sub.Rules {
+			subRule.Rules = append(subRule.Rules, roleSourceRule{
+				Type:         leaf.Type.ValueString(),
+				Source:       leaf.Source.ValueString(),
+				SearchString: leaf.SearchString.ValueString(),
+				Pattern:      leaf.Pattern.ValueString(),
+				Rules:        []roleSourceRule{},
+			})
+		}
+		rule.Rules = append(rule.Rules, subRule)
+	}
+	return rule
+}
+
+// optionalString maps the empty strings PrivX returns for unset attributes to
+// null.
+func optionalString(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
+// newRoleSourceRuleModel converts source rules read from PrivX. Rules nested
+// deeper than the schema allows are not represented.
+func newRoleSourceRuleModel(rule roleSourceRule) *RoleSourceRuleModel {
+	m := &RoleSourceRuleModel{
+		Type:         types.StringValue(rule.Type),
+		Match:        types.StringValue(rule.Match),
+		Source:       optionalString(rule.Source),
+		SearchString: optionalString(rule.SearchString),
+		Pattern:      optionalString(rule.Pattern),
+		Rules:        []RoleSourceSubRuleModel{},
+	}
+	for _, sub := range rule.Rules {
+		subModel := RoleSourceSubRuleModel{
+			Type:         types.StringValue(sub.Type),
+			Match:        types.StringValue(sub.Match),
+			Source:       optionalString(sub.Source),
+			SearchString: optionalString(sub.SearchString),
+			Pattern:      optionalString(sub.Pattern),
+			Rules:        []RoleSourceLeafRuleModel{},
+		}
+		for _, leaf := range sub.Rules {
+			subModel.Rules = append(subModel.Rules, RoleSourceLeafRuleModel{
+				Type:         types.StringValue(leaf.Type),
+				Source:       optionalString(leaf.Source),
+				SearchString: optionalString(leaf.SearchString),
+				Pattern:      optionalString(leaf.Pattern),
+			})
+		}
+		m.Rules = append(m.Rules, subModel)
+	}
+	return m
+}
+
+// The role store calls below go through the connector directly, as
+// rolestore.Role drops the pattern of source rules.
+
+func createRole(connector restapi.Connector, role *roleWithSourceRule) (string, error) {
+	var object struct {
+		ID string `json:"id"`
+	}
+
+	_, err := connector.
+		URL("/role-store/api/v1/roles").
+		Post(role, &object)
+
+	return object.ID, err
+}
+
+func getRole(connector restapi.Connector, roleID string) (*roleWithSourceRule, error) {
+	role := &roleWithSourceRule{}
+
+	_, err := connector.
+		URL("/role-store/api/v1/roles/%s", url.PathEscape(roleID)).
+		Get(role)
+
+	return role, err
+}
+
+func updateRole(connector restapi.Connector, roleID string, role *roleWithSourceRule) error {
+	_, err := connector.
+		URL("/role-store/api/v1/roles/%s", url.PathEscape(roleID)).
+		Put(role)
+
+	return err
+}
+
+// listRoles returns all the roles, walking through the result pages of the
+// role store API.
+func listRoles(connector restapi.Connector) ([]roleWithSourceRule, error) {
+	const pageSize = 100
+
+	var roles []roleWithSourceRule
+	for offset := 0; ; offset += pageSize {
+		var page struct {
+			Count int                  `json:"count"`
+			Items []roleWithSourceRule `json:"items"`
+		}
+		_, err := connector.
+			URL("/role-store/api/v1/roles").
+			Query(&rolestore.Params{Offset: offset, Limit: pageSize}).
+			Get(&page)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, page.Items...)
+		if len(page.Items) == 0 || len(roles) >= page.Count {
+			return roles, nil
+		}
+	}
+}
+
+// hasSource reports whether the rule, or one of its nested rules, applies to
+// the source.
+func (r roleSourceRule) hasSource(source string) bool {
+	if r.Source == source {
+		return true
+	}
+	for _, rule := range r.Rules {
+		if rule.hasSource(source) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RolesDataSource{}
+
+func NewRolesDataSource() datasource.DataSource {
+	return &RolesDataSource{}
+}
+
+// RolesDataSource defines the data source implementation.
+type RolesDataSource struct {
+	endpoints
+	connector restapi.Connector
+}
+
+// RolesDataSourceModel describes the data source data model.
+type (
+	RoleSummaryModel struct {
+		ID            types.String `tfsdk:"id"`
+		Name          types.String `tfsdk:"name"`
+		Comment       types.String `tfsdk:"comment"`
+		AccessGroupID types.String `tfsdk:"access_group_id"`
+		Permissions   types.Set    `tfsdk:"permissions"`
+		PublicKey     types.Set    `tfsdk:"principal_public_key_strings"`
+		PermitAgent   types.Bool   `tfsdk:"permit_agent"`
+	}
+
+	RolesDataSourceModel struct {
+		NameRegex     types.String       `tfsdk:"name_regex"`
+		Permission    types.String       `tfsdk:"permission"`
+		AccessGroupID types.String       `tfsdk:"access_group_id"`
+		Source        types.String       `tfsdk:"source"`
+		Roles         []RoleSummaryModel `tfsdk:"roles"`
+		IDs           types.List         `tfsdk:"ids"`
+		Names         types.List         `tfsdk:"names"`
+		Endpoint      types.String       `tfsdk:"endpoint"`
+	}
+)
+
+func (d *RolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *RolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Roles data source. Lists the roles matching all the given filters, every role when no filter is set.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the names of the roles match, in the RE2 syntax",
+				Optional:            true,
+			},
+			"permission": schema.StringAttribute{
+				MarkdownDescription: "Permission the roles grant",
+				Optional:            true,
+			},
+			"access_group_id": schema.StringAttribute{
+				MarkdownDescription: "Access group the roles are scoped to",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "ID of a source the source rules of the roles map users from",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the matching roles",
+				Computed:            true,
+			},
+			"names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the matching roles",
+				Computed:            true,
+			},
+			"roles": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching roles",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Role ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the role",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "A comment describing the object",
+							Computed:            true,
+						},
+						"access_group_id": schema.StringAttribute{
+							MarkdownDescription: "Access group host and connection permissions are scoped to",
+							Computed:            true,
+						},
+						"permissions": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "Role permissions",
+							Computed:            true,
+						},
+						"principal_public_key_strings": schema.SetAttribute{
+							ElementType:         types.StringType,
+							MarkdownDescription: "List of role's principal public keys",
+							Computed:            true,
+						},
+						"permit_agent": schema.BoolAttribute{
+							MarkdownDescription: "Role permit agent",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RolesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
+
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *RolesDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"endpoint": endpoint.ValueString(),
+	})
+
+	d.connector = connector
+	return diags
+}
+
+func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RolesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression",
+				fmt.Sprintf("Unable to parse name_regex, got error: %s", err))
+			return
+		}
+	}
+
+	roles, err := listRoles(d.connector)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list roles, got error: %s", err))
+		return
+	}
+
+	data.Roles = []RoleSummaryModel{}
+	ids := []string{}
+	names := []string{}
+	for _, role := range roles {
+		if nameRegex != nil && !nameRegex.MatchString(role.Name) {
+			continue
+		}
+		if !data.Permission.IsNull() && !slices.Contains(role.Permissions, data.Permission.ValueString()) {
+			continue
+		}
+		if !data.AccessGroupID.IsNull() && role.AccessGroupID != data.AccessGroupID.ValueString() {
+			continue
+		}
+		if !data.Source.IsNull() && !role.SourceRule.hasSource(data.Source.ValueString()) {
+			continue
+		}
+
+		permissions, diags := types.SetValueFrom(ctx, types.StringType, role.Permissions)
+		resp.Diagnostics.Append(diags...)
+		publicKey, diags := types.SetValueFrom(ctx, types.StringType, role.PublicKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ids = append(ids, role.ID)
+		names = append(names, role.Name)
+		data.Roles = append(data.Roles, RoleSummaryModel{
+			ID:            types.StringValue(role.ID),
+			Name:          types.StringValue(role.Name),
+			Comment:       types.StringValue(role.Comment),
+			AccessGroupID: types.StringValue(role.AccessGroupID),
+			Permissions:   permissions,
+			PublicKey:     publicKey,
+			PermitAgent:   types.BoolValue(role.PermitAgent),
+		})
+	}
+
+	var diags diag.Diagnostics
+	data.IDs, diags = types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	data.Names, diags = types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Storing roles into the state", map[string]interface{}{
+		"roleCount": len(data.Roles),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
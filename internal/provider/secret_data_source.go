@@ -4,11 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/vault"
-	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -22,19 +23,21 @@ func NewSecretDataSource() datasource.DataSource {
 
 // SecretDataSource defines the data source implementation.
 type SecretDataSource struct {
+	endpoints
 	client *vault.Vault
 }
 
 // SecretDataSourceModel describes the data source data model.
 type SecretDataSourceModel struct {
 	Name       types.String   `tfsdk:"name"`
-	Data       types.String   `tfsdk:"data"`
+	Data       utils.JSON     `tfsdk:"data"`
 	Author     types.String   `tfsdk:"author"`
 	UpdatedBy  types.String   `tfsdk:"updated_by"`
 	Created    types.String   `tfsdk:"created"`
 	Updated    types.String   `tfsdk:"updated"`
 	AllowRead  []RoleRefModel `tfsdk:"read_roles"`
 	AllowWrite []RoleRefModel `tfsdk:"write_roles"`
+	Endpoint   types.String   `tfsdk:"endpoint"`
 }
 
 func (d *SecretDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -46,12 +49,14 @@ func (d *SecretDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Example data source",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Secret's name",
 				Required:            true,
 			},
 			"data": schema.StringAttribute{
-				MarkdownDescription: "Secret to be stored",
+				MarkdownDescription: "Secret to be stored, as a JSON document",
+				CustomType:          utils.JSONType{},
 				Computed:            true,
 				Sensitive:           true,
 			},
@@ -113,20 +118,21 @@ func (d *SecretDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(d.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (d *SecretDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := d.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating vault client", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	d.client = vault.New(*connector)
+	d.client = vault.New(connector)
+	return diags
 }
 
 func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -139,6 +145,11 @@ func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(d.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	secret, err := d.client.Secret(data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret, got error: %s", err))
@@ -166,7 +177,7 @@ func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		)
 		return
 	}
-	data.Data = types.StringValue(string(secretData))
+	data.Data = utils.NewJSONValue(string(secretData))
 
 	data.Author = types.StringValue(secret.Author)
 	data.Created = types.StringValue(secret.Created)
@@ -174,7 +185,7 @@ func (d *SecretDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.UpdatedBy = types.StringValue(secret.Editor)
 
 	tflog.Debug(ctx, "Storing secret type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/vault"
-	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,6 +27,7 @@ func NewSecretResource() resource.Resource {
 
 // SecretResource defines the resource implementation.
 type SecretResource struct {
+	endpoints
 	client *vault.Vault
 }
 
@@ -37,9 +40,10 @@ type (
 
 	SecretResourceModel struct {
 		Name       types.String   `tfsdk:"name"`
-		Data       types.String   `tfsdk:"data"`
+		Data       utils.JSON     `tfsdk:"data"`
 		ReadRoles  []RoleRefModel `tfsdk:"read_roles"`
 		WriteRoles []RoleRefModel `tfsdk:"write_roles"`
+		Endpoint   types.String   `tfsdk:"endpoint"`
 	}
 )
 
@@ -52,12 +56,14 @@ func (r *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Secret resource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Secret's name",
 				Required:            true,
 			},
 			"data": schema.StringAttribute{
-				MarkdownDescription: "Secret to be stored",
+				MarkdownDescription: "Secret to be stored, as a JSON document",
+				CustomType:          utils.JSONType{},
 				Optional:            true,
 				Computed:            true,
 				Sensitive:           true,
@@ -105,20 +111,21 @@ func (r *SecretResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *SecretResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating vault client", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = vault.New(*connector)
+	r.client = vault.New(connector)
+	return diags
 }
 
 func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -131,8 +138,13 @@ func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Loaded secret type data", map[string]interface{}{
-		"data": fmt.Sprintf("%+v", data),
+		"data": utils.Redacted(data),
 	})
 
 	var readRolesPayload []string
@@ -146,12 +158,8 @@ func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	var secretPayload interface{}
-	if err := json.Unmarshal([]byte(data.Data.ValueString()), &secretPayload); err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Cannot unmarshal secret data json.\n"+
-				err.Error(),
-		)
+	resp.Diagnostics.Append(data.Data.Unmarshal(&secretPayload)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -184,7 +192,17 @@ func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	secret, err := r.client.Secret(data.Name.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "secret not found in PrivX, removing it from the state", map[string]interface{}{"name": data.Name.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret : %s, got error: %s", data.Name.ValueString(), err))
 		return
@@ -211,10 +229,10 @@ func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, res
 		)
 		return
 	}
-	data.Data = types.StringValue(string(secretData))
+	data.Data = utils.NewJSONValue(string(secretData))
 
 	tflog.Debug(ctx, "Storing secret type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -226,6 +244,11 @@ func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name_from_state)...)
 
 	if resp.Diagnostics.HasError() {
@@ -243,12 +266,8 @@ func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	var secretPayload interface{}
-	if err := json.Unmarshal([]byte(data.Data.ValueString()), &secretPayload); err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Resource",
-			"Cannot unmarshal secret data json.\n"+
-				err.Error(),
-		)
+	resp.Diagnostics.Append(data.Data.Unmarshal(&secretPayload)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -292,6 +311,11 @@ func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -303,5 +327,5 @@ func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	r.importState(ctx, path.Root("name"), req, resp)
 }
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/vault"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSecretResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_secret", "name", func(connector restapi.Connector, name string) error {
+			_, err := vault.New(connector).Secret(name)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSecretResourceConfig(name, `{"password":"first"}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_secret.test", "name", name),
+					resource.TestCheckResourceAttr("privx_secret.test", "data", `{"password":"first"}`),
+					resource.TestCheckResourceAttr("privx_secret.test", "read_roles.#", "1"),
+					resource.TestCheckResourceAttr("privx_secret.test", "write_roles.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "privx_secret.test",
+				ImportState:                          true,
+				ImportStateId:                        name,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+			// Update and Read testing
+			{
+				Config: testAccSecretResourceConfig(name, `{"password":"second"}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_secret.test", "data", `{"password":"second"}`),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSecretResourceSemanticJSON(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSecretResourceConfig(name, `{"password": "first"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid JSON Value"),
+			},
+			// PrivX returns the document reformatted, which must not show as a change
+			{
+				Config: testAccSecretResourceConfig(name, `{ "username": "admin", "password": "first" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_secret.test", "data", `{ "username": "admin", "password": "first" }`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSecretDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecretResourceConfig(name, `{"password":"first"}`) + `
+data "privx_secret" "test" {
+  name = privx_secret.test.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_secret.test", "data", `{"password":"first"}`),
+					resource.TestCheckResourceAttr("data.privx_secret.test", "read_roles.#", "1"),
+					resource.TestCheckResourceAttrSet("data.privx_secret.test", "author"),
+					resource.TestCheckResourceAttrSet("data.privx_secret.test", "created"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSecretResourceConfig(name, data string) string {
+	return fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name = %[1]q
+}
+
+resource "privx_role" "test" {
+  name            = %[1]q
+  access_group_id = privx_access_group.test.id
+}
+
+resource "privx_secret" "test" {
+  name = %[1]q
+  data = %[2]q
+  read_roles = [
+    {
+      id   = privx_role.test.id
+      name = privx_role.test.name
+    },
+  ]
+  write_roles = [
+    {
+      id   = privx_role.test.id
+      name = privx_role.test.name
+    },
+  ]
+}
+`, name, data)
+}
@@ -3,10 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/rolestore"
-	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -27,6 +29,7 @@ func NewSourceResource() resource.Resource {
 
 // SourceResource defines the resource implementation.
 type SourceResource struct {
+	endpoints
 	client *rolestore.RoleStore
 }
 
@@ -58,6 +61,7 @@ type (
 		UsernamePattern     types.List           `tfsdk:"username_pattern"`
 		ExternalUserMapping []*EUMModel          `tfsdk:"external_user_mapping"`
 		OIDCConnection      *OIDCConnectionModel `tfsdk:"oidc_connection"`
+		Endpoint            types.String         `tfsdk:"endpoint"`
 	}
 )
 
@@ -71,6 +75,7 @@ func (r *SourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Source resource",
 
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointResourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Source ID",
 				Computed:            true,
@@ -166,17 +171,17 @@ func (r *SourceResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the resource.
+func (r *SourceResource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
-
-	r.client = rolestore.New(*connector)
+	r.client = rolestore.New(connector)
+	return diags
 }
 
 func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -189,6 +194,11 @@ func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tagsPayload := make([]string, len(data.Tags.Elements()))
 	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tagsPayload, false)...)
 	if resp.Diagnostics.HasError() {
@@ -252,7 +262,7 @@ func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 	// and set any unknown attribute values.
 	data.ID = types.StringValue(sourceID)
 
-	tflog.Info(ctx, fmt.Sprintf("data stored: %+v", data))
+	tflog.Info(ctx, "data stored: "+utils.Redacted(data))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -268,8 +278,18 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Get the source object from PrivX API
 	source, err := r.client.Source(data.ID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "source not found in PrivX, removing it from the state", map[string]interface{}{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read source, got error: %s", err))
 		return
@@ -292,18 +312,25 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 			types.StringValue(v.SourceSeaerchField)})
 	}
 
-	scopesSecret, diags := types.ListValueFrom(ctx, data.OIDCConnection.ScopesSecret.ElementType(ctx), source.Connection.OIDCScopesSecret)
+	scopesSecret, diags := types.ListValueFrom(ctx, types.StringType, source.Connection.OIDCScopesSecret)
 	if diags.HasError() {
 		return
 	}
 
+	// Do not update client_secret. We keep the state value since PrivX returns "*****" as password.
+	// There is no state value yet when importing.
+	clientSecret := types.StringNull()
+	if data.OIDCConnection != nil {
+		clientSecret = data.OIDCConnection.ClientSecret
+	}
+
 	connection := &OIDCConnectionModel{
 		Address:           types.StringValue(source.Connection.Address),
 		Enabled:           types.BoolValue(source.Connection.OIDCEnabled),
 		ButtonTitle:       types.StringValue(source.Connection.OIDCButtonTitle),
 		Issuer:            types.StringValue(source.Connection.OIDCIssuer),
 		ClientID:          types.StringValue(source.Connection.OIDCClientID),
-		ClientSecret:      data.OIDCConnection.ClientSecret, // Do not update client_secret. We keep the state value since PrivX returns "*****" as password.
+		ClientSecret:      clientSecret,
 		TagsAttributeName: types.StringValue(source.Connection.OIDCTagsAttributeName),
 		ScopesSecret:      scopesSecret,
 	}
@@ -317,7 +344,7 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	data.ExternalUserMapping = eum
 	data.OIDCConnection = connection
 
-	tflog.Info(ctx, fmt.Sprintf("data stored: %+v", data))
+	tflog.Info(ctx, "data stored: "+utils.Redacted(data))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -333,6 +360,11 @@ func (r *SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tagsPayload := make([]string, len(data.Tags.Elements()))
 	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tagsPayload, false)...)
 	if resp.Diagnostics.HasError() {
@@ -390,7 +422,7 @@ func (r *SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	tflog.Info(ctx, fmt.Sprintf("data stored: %+v", data))
+	tflog.Info(ctx, "data stored: "+utils.Redacted(data))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -406,6 +438,11 @@ func (r *SourceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteSource(data.ID.ValueString())
 
 	if err != nil {
@@ -415,5 +452,5 @@ func (r *SourceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *SourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	r.importState(ctx, path.Root("id"), req, resp)
 }
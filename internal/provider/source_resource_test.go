@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/restapi"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourceResource(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroy(t, "privx_source", "id", func(connector restapi.Connector, id string) error {
+			_, err := rolestore.New(connector).Source(id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSourceResourceConfig(name, "first comment", "Login"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_source.test", "name", name),
+					resource.TestCheckResourceAttr("privx_source.test", "comment", "first comment"),
+					resource.TestCheckResourceAttr("privx_source.test", "ttl", "900"),
+					resource.TestCheckResourceAttr("privx_source.test", "oidc_connection.button_title", "Login"),
+					resource.TestCheckResourceAttrSet("privx_source.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "privx_source.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"oidc_connection.client_secret"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccSourceResourceConfig(name, "second comment", "Sign in"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("privx_source.test", "comment", "second comment"),
+					resource.TestCheckResourceAttr("privx_source.test", "oidc_connection.button_title", "Sign in"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSourceResourceConfig(name, comment, buttonTitle string) string {
+	return fmt.Sprintf(`
+resource "privx_source" "test" {
+  name             = %[1]q
+  comment          = %[2]q
+  enabled          = true
+  tags             = ["oidc"]
+  username_pattern = ["$${email}"]
+
+  oidc_connection = {
+    address                  = "https://idp.example.com"
+    enabled                  = true
+    issuer                   = "https://idp.example.com"
+    button_title             = %[3]q
+    client_id                = "privx"
+    client_secret            = "secret"
+    tags_attribute_name      = "groups"
+    additional_scopes_secret = ["email"]
+  }
+}
+`, name, comment, buttonTitle)
+}
@@ -3,11 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/api/userstore"
-	"github.com/SSHcom/privx-sdk-go/restapi"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -21,6 +22,7 @@ func NewWebproxyDataSource() datasource.DataSource {
 
 // WebproxyDataSource defines the DataSource implementation.
 type WebproxyDataSource struct {
+	endpoints
 	client *userstore.UserStore
 }
 
@@ -37,6 +39,7 @@ type WebproxyDataSourceModel struct {
 	Subnets         types.List   `tfsdk:"subnets"`
 	Registered      types.Bool   `tfsdk:"registered"`
 	GroupId         types.String `tfsdk:"group_id"`
+	Endpoint        types.String `tfsdk:"endpoint"`
 }
 
 func (r *WebproxyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -48,6 +51,7 @@ func (r *WebproxyDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Carrier DataSource",
 		Attributes: map[string]schema.Attribute{
+			"endpoint": endpointDataSourceAttribute(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Carrier ID",
 				Computed:            true,
@@ -106,20 +110,21 @@ func (r *WebproxyDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	connector, ok := req.ProviderData.(*restapi.Connector)
+	resp.Diagnostics.Append(r.configure(req.ProviderData)...)
+}
 
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected DataSource Configure Type",
-			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-		return
+// connect binds the client to the PrivX endpoint of the data source.
+func (r *WebproxyDataSource) connect(ctx context.Context, endpoint types.String) diag.Diagnostics {
+	connector, diags := r.endpoints.connector(endpoint)
+	if diags.HasError() {
+		return diags
 	}
 	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
-		"connector : ": fmt.Sprintf("%+v", *connector),
+		"endpoint": endpoint.ValueString(),
 	})
 
-	r.client = userstore.New(*connector)
+	r.client = userstore.New(connector)
+	return diags
 }
 
 func (r *WebproxyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -132,6 +137,11 @@ func (r *WebproxyDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	resp.Diagnostics.Append(r.connect(ctx, data.Endpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	trustedClientList, err := r.client.TrustedClients()
 
 	if err != nil {
@@ -181,7 +191,7 @@ func (r *WebproxyDataSource) Read(ctx context.Context, req datasource.ReadReques
 	data.ExtenderAddress = extenderAddress
 
 	tflog.Debug(ctx, "Storing webproxy type into the state", map[string]interface{}{
-		"createNewState": fmt.Sprintf("%+v", data),
+		"createNewState": utils.Redacted(data),
 	})
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
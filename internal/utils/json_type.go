@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the JSON types fully satisfy framework interfaces.
+var (
+	_ basetypes.StringTypable                    = JSONType{}
+	_ xattr.TypeWithValidate                     = JSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = JSON{}
+)
+
+// JSONType is a string attribute type holding a JSON document. Invalid JSON
+// is rejected when the configuration is validated, and documents that only
+// differ by formatting or key order are considered equal.
+type JSONType struct {
+	basetypes.StringType
+}
+
+func (t JSONType) String() string {
+	return "utils.JSONType"
+}
+
+func (t JSONType) ValueType(ctx context.Context) attr.Value {
+	return JSON{}
+}
+
+func (t JSONType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t JSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSON{StringValue: in}, nil
+}
+
+func (t JSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// Validate rejects values that are not valid JSON.
+func (t JSONType) Validate(ctx context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if in.Type() == nil || !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var value string
+	if err := in.As(&value); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON Value", "Cannot convert value to string.\n"+err.Error())
+		return diags
+	}
+
+	if !json.Valid([]byte(value)) {
+		diags.AddAttributeError(p, "Invalid JSON Value",
+			fmt.Sprintf("A string value was provided that is not valid JSON: %q", value))
+	}
+	return diags
+}
+
+// JSON is a value of JSONType.
+type JSON struct {
+	basetypes.StringValue
+}
+
+// NewJSONValue returns a known JSON value.
+func NewJSONValue(value string) JSON {
+	return JSON{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewJSONNull returns a null JSON value.
+func NewJSONNull() JSON {
+	return JSON{StringValue: basetypes.NewStringNull()}
+}
+
+// NewJSONUnknown returns an unknown JSON value.
+func NewJSONUnknown() JSON {
+	return JSON{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v JSON) Type(ctx context.Context) attr.Type {
+	return JSONType{}
+}
+
+func (v JSON) Equal(o attr.Value) bool {
+	other, ok := o.(JSON)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values hold the same JSON
+// document, so that reformatting it does not show as a change.
+func (v JSON) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSON)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable))
+		return false, diags
+	}
+
+	equal, err := JSONBytesEqual([]byte(v.ValueString()), []byte(newValue.ValueString()))
+	if err != nil {
+		// Invalid JSON is never equal to anything but itself
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+	return equal, diags
+}
+
+// Unmarshal decodes the JSON document into target.
+func (v JSON) Unmarshal(target interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := json.Unmarshal([]byte(v.ValueString()), target); err != nil {
+		diags.AddError("Invalid JSON Value", "Cannot unmarshal JSON.\n"+err.Error())
+	}
+	return diags
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Redaction replaces secret values in logs.
+const Redaction = "***"
+
+// secretKey matches the names of fields holding secrets: credentials, vault
+// secret data, and the trusted client configurations which embed their
+// OAuth secrets.
+var secretKey = regexp.MustCompile(`(?i)(secret|passphrase|password|token|private_?key|digest|cookie|authorization|(^|_)config$|^data$)`)
+
+// IsSecretKey reports whether a field of that name holds a secret.
+func IsSecretKey(name string) bool {
+	return secretKey.MatchString(name)
+}
+
+// Redacted formats v like the %+v verb, with the fields holding secrets
+// replaced. Fields are matched by name and by tfsdk and json tags.
+func Redacted(v interface{}) string {
+	var b strings.Builder
+	writeRedacted(&b, reflect.ValueOf(v))
+	return b.String()
+}
+
+func writeRedacted(b *strings.Builder, v reflect.Value) {
+	if !v.IsValid() {
+		b.WriteString("<nil>")
+		return
+	}
+
+	if v.CanInterface() {
+		if s, ok := v.Interface().(fmt.Stringer); ok && v.Kind() == reflect.Struct {
+			b.WriteString(s.String())
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		if v.Kind() == reflect.Pointer {
+			b.WriteString("&")
+		}
+		writeRedacted(b, v.Elem())
+	case reflect.Struct:
+		b.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(field.Name + ":")
+			if isSecretField(field) {
+				b.WriteString(Redaction)
+				continue
+			}
+			writeRedacted(b, v.Field(i))
+		}
+		b.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprint(b, v)
+			return
+		}
+		b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			writeRedacted(b, v.Index(i))
+		}
+		b.WriteString("]")
+	case reflect.Map:
+		b.WriteString("map[")
+		for i, key := range v.MapKeys() {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			fmt.Fprint(b, key)
+			b.WriteString(":")
+			if key.Kind() == reflect.String && IsSecretKey(key.String()) {
+				b.WriteString(Redaction)
+				continue
+			}
+			writeRedacted(b, v.MapIndex(key))
+		}
+		b.WriteString("]")
+	default:
+		fmt.Fprint(b, v)
+	}
+}
+
+func isSecretField(field reflect.StructField) bool {
+	if IsSecretKey(field.Name) {
+		return true
+	}
+	for _, tag := range []string{"tfsdk", "json"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && IsSecretKey(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactBody returns a JSON or form encoded body with the values of the
+// fields holding secrets replaced. Other bodies, such as configuration
+// files, are not returned, only their size.
+func RedactBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			for key := range values {
+				if IsSecretKey(key) {
+					values.Set(key, Redaction)
+				}
+			}
+			return values.Encode()
+		}
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	redacted, err := json.Marshal(redactJSON(document))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return string(redacted)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if IsSecretKey(key) && value != nil {
+				v[key] = Redaction
+				continue
+			}
+			v[key] = redactJSON(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+	}
+	return v
+}
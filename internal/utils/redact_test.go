@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRedacted(t *testing.T) {
+	type role struct {
+		ID types.String `tfsdk:"id"`
+	}
+	model := struct {
+		Name          types.String `tfsdk:"name"`
+		Data          JSON         `tfsdk:"data"`
+		OAuthSecret   types.String `tfsdk:"oauth_client_secret"`
+		CarrierConfig types.String `tfsdk:"carrier_config"`
+		Roles         []role       `tfsdk:"roles"`
+		Options       map[string]string
+	}{
+		Name:          types.StringValue("visible"),
+		Data:          NewJSONValue(`{"password":"hidden"}`),
+		OAuthSecret:   types.StringValue("hidden"),
+		CarrierConfig: types.StringValue("hidden"),
+		Roles:         []role{{ID: types.StringValue("role-id")}},
+		Options:       map[string]string{"passphrase": "hidden", "user": "visible"},
+	}
+
+	got := Redacted(&model)
+	if strings.Contains(got, "hidden") {
+		t.Errorf("expected secrets to be redacted, got %s", got)
+	}
+	for _, want := range []string{`Name:"visible"`, `OAuthSecret:***`, `Roles:[{ID:"role-id"}]`, `user:visible`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %s in %s", want, got)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	for _, tc := range []struct {
+		body        string
+		contentType string
+		want        string
+	}{
+		{`{"name":"vault","data":{"password":"hidden"}}`, "application/json", `{"data":"***","name":"vault"}`},
+		{`{"items":[{"access_token":"hidden","expires_in":3600}]}`, "application/json", `{"items":[{"access_token":"***","expires_in":3600}]}`},
+		{`grant_type=password&password=hidden&username=visible`, "application/x-www-form-urlencoded", `grant_type=password&password=%2A%2A%2A&username=visible`},
+		{"client_secret = hidden\n", "text/plain", "<23 bytes>"},
+	} {
+		if got := RedactBody([]byte(tc.body), tc.contentType); got != tc.want {
+			t.Errorf("expected %s, got %s", tc.want, got)
+		}
+	}
+}